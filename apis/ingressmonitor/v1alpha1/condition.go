@@ -0,0 +1,83 @@
+package v1alpha1
+
+import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionType describes the type of state a Condition represents.
+type ConditionType string
+
+const (
+	// ConditionReady indicates whether or not the resource is fully set up
+	// with the provider.
+	ConditionReady ConditionType = "Ready"
+
+	// ConditionReferencesResolved indicates whether or not the Provider and
+	// MonitorTemplate referenced by a Monitor are available.
+	ConditionReferencesResolved ConditionType = "ReferencesResolved"
+
+	// ConditionSelectorValid indicates whether or not the selector of a
+	// Monitor can be used to select Ingresses.
+	ConditionSelectorValid ConditionType = "SelectorValid"
+
+	// ConditionAnnotationsValid indicates whether or not the check settings
+	// configured through annotations on the resources selected by a Monitor
+	// are valid.
+	ConditionAnnotationsValid ConditionType = "AnnotationsValid"
+
+	// ConditionCredentialsResolved indicates whether or not a client for the
+	// provider of an IngressMonitor could be set up with the configured
+	// credentials.
+	ConditionCredentialsResolved ConditionType = "CredentialsResolved"
+
+	// ConditionProviderSynced indicates whether or not the last sync of an
+	// IngressMonitor with its provider succeeded.
+	ConditionProviderSynced ConditionType = "ProviderSynced"
+
+	// ConditionCertificateValid indicates whether or not the certificate
+	// checked by the Operator, for providers which don't support certificate
+	// checks, is valid and doesn't expire soon.
+	ConditionCertificateValid ConditionType = "CertificateValid"
+
+	// ConditionAssertionsSupported indicates whether or not the provider of
+	// an IngressMonitor could express all the assertions of its HTTP check.
+	ConditionAssertionsSupported ConditionType = "AssertionsSupported"
+
+	// ConditionMaintenanceWindowsValid indicates whether or not the
+	// maintenance windows of a Monitor can be evaluated.
+	ConditionMaintenanceWindowsValid ConditionType = "MaintenanceWindowsValid"
+
+	// ConditionRolloutPolicyValid indicates whether or not the rollout
+	// policy of a Monitor can be applied.
+	ConditionRolloutPolicyValid ConditionType = "RolloutPolicyValid"
+
+	// ConditionPaused indicates whether or not the check of an IngressMonitor
+	// is paused with its provider.
+	ConditionPaused ConditionType = "Paused"
+)
+
+// Condition describes the state of a resource at a certain point in time.
+type Condition struct {
+	// Type is the type of the condition.
+	Type ConditionType `json:"type"`
+
+	// Status is the status of the condition, one of `True`, `False` or
+	// `Unknown`.
+	Status v1.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the last time the condition transitioned from one
+	// status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a machine readable explanation for the condition's last
+	// transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the last
+	// transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
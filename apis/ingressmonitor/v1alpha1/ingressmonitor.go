@@ -12,6 +12,25 @@ type IngressMonitorSpec struct {
 
 	// Template describes the monitor configuration.
 	Template MonitorTemplateSpec `json:"template"`
+
+	// MaintenanceWindow is the name of the maintenance window of the Monitor
+	// which is currently active for this IngressMonitor. The check is paused
+	// with the provider for as long as it's set.
+	// +optional
+	MaintenanceWindow string `json:"maintenanceWindow,omitempty"`
+
+	// Paused pauses the check with the provider. It's set when the Monitor
+	// or the Ingress, Service or HTTPRoute of the IngressMonitor has the
+	// `ingressmonitor.sphc.io/paused` annotation.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Rollout is the Deployment or StatefulSet serving the target of the
+	// IngressMonitor which is being rolled out, like `Deployment/api`. It's
+	// only set when the rollout policy of the Monitor pauses checks, the
+	// check is paused with the provider for as long as it's set.
+	// +optional
+	Rollout string `json:"rollout,omitempty"`
 }
 
 // IngressMonitorStatus describes the status of an IngressMonitor. This is data
@@ -23,6 +42,52 @@ type IngressMonitorStatus struct {
 
 	// IngressName is the name of the Ingress this IngressMonitor is linked to.
 	IngressName string `json:"ingressName"`
+
+	// ServiceName is the name of the Service this IngressMonitor is linked
+	// to, for IngressMonitors which are set up for a Service instead of an
+	// Ingress.
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+
+	// HTTPRouteName is the name of the HTTPRoute this IngressMonitor is
+	// linked to, for IngressMonitors which are set up for an HTTPRoute.
+	// +optional
+	HTTPRouteName string `json:"httpRouteName,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the IngressMonitor
+	// which has been synced with the provider.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the last time the IngressMonitor has been synced with
+	// the provider. Syncs which don't change anything else in the status only
+	// refresh it every five minutes.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastError is the error message of the last failed sync. This is empty
+	// when the last sync succeeded.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// Certificate describes the certificate the Operator has checked itself,
+	// for certificate checks which aren't supported by the provider.
+	// +optional
+	Certificate *CertificateStatus `json:"certificate,omitempty"`
+
+	// Conditions describes the observed state of the IngressMonitor.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// CertificateStatus describes a certificate which has been checked by the
+// Operator.
+type CertificateStatus struct {
+	// SecretName is the name of the Secret the certificate has been read from.
+	SecretName string `json:"secretName"`
+
+	// NotAfter is the time at which the certificate expires.
+	NotAfter metav1.Time `json:"notAfter"`
 }
 
 // NamespacedProvider contains all the details about a provider, including the
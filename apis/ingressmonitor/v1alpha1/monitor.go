@@ -3,13 +3,51 @@ package v1alpha1
 import (
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // MonitorSpec is the detailed configuration for an Monitor.
 type MonitorSpec struct {
 	// Selector describes the LabelSelector which will be used to select the
-	// enabled Ingresses which we want to set up monitors for.
-	Selector *metav1.LabelSelector `json:"selector"`
+	// enabled Ingresses which we want to set up monitors for. When no
+	// selector is given, no Ingresses are selected.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// IngressClassName limits the Monitor to Ingresses of the given class.
+	// The class is read from the `ingressClassName` field of the Ingress, or
+	// the `kubernetes.io/ingress.class` annotation for older Ingresses.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// PerPath sets up a monitor for every path of an Ingress rule instead of
+	// one for every host. The endpoint of the MonitorTemplate is appended to
+	// each path.
+	// +optional
+	PerPath bool `json:"perPath,omitempty"`
+
+	// Services configures the Monitor to set up monitors for Services of type
+	// LoadBalancer as well.
+	// +optional
+	Services *ServiceSelector `json:"services,omitempty"`
+
+	// HTTPRoutes configures the Monitor to set up monitors for Gateway API
+	// HTTPRoutes as well.
+	// +optional
+	HTTPRoutes *HTTPRouteSelector `json:"httpRoutes,omitempty"`
+
+	// MaintenanceWindows describe when the checks of the Monitor are paused
+	// with the provider, for example during planned migrations. The checks
+	// are resumed once the window has passed.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// Rollouts configures how the checks of the Monitor behave while a
+	// Deployment or StatefulSet behind one of the selected Ingresses,
+	// Services or HTTPRoutes is being rolled out. When it isn't set, the
+	// checks aren't affected by rollouts.
+	// +optional
+	Rollouts *RolloutPolicy `json:"rollouts,omitempty"`
 
 	// Provider describes the provider we want to use to set up the monitor
 	// with.
@@ -19,6 +57,133 @@ type MonitorSpec struct {
 	Template v1.LocalObjectReference `json:"template"`
 }
 
+// ServiceSelector describes which Services of type LoadBalancer are selected
+// by a Monitor and how their monitors are set up. The monitor URL is built from
+// the address of the LoadBalancer and the selected port.
+type ServiceSelector struct {
+	// Selector describes the LabelSelector which will be used to select the
+	// Services we want to set up monitors for.
+	Selector *metav1.LabelSelector `json:"selector"`
+
+	// Port is the name or number of the Service port which is monitored.
+	// Defaults to the first port of the Service.
+	// +optional
+	Port *intstr.IntOrString `json:"port,omitempty"`
+}
+
+// HTTPRouteSelector describes which Gateway API HTTPRoutes are selected by a
+// Monitor. Every hostname of a selected HTTPRoute is monitored.
+type HTTPRouteSelector struct {
+	// Selector describes the LabelSelector which will be used to select the
+	// HTTPRoutes we want to set up monitors for.
+	Selector *metav1.LabelSelector `json:"selector"`
+}
+
+// MaintenanceWindow describes a period in which the checks of a Monitor are
+// paused. A window is either recurring, with a schedule and a duration, or
+// happens once, between a start and an end time.
+type MaintenanceWindow struct {
+	// Name identifies the window in the status and Events of the Monitor and
+	// its IngressMonitors.
+	Name string `json:"name"`
+
+	// Schedule is a cron expression with five fields, minute, hour, day of
+	// month, month and day of week, which describes when a recurring window
+	// starts. Schedules are evaluated in UTC.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Duration describes how long a recurring window lasts, for example `2h`.
+	// It's required with a schedule and can be at most a week.
+	// +optional
+	Duration string `json:"duration,omitempty"`
+
+	// Start is the time at which a one-off window starts, in RFC3339.
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+
+	// End is the time at which a one-off window ends, in RFC3339.
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+
+	// Selector limits the window to the Ingresses, Services and HTTPRoutes
+	// selected by the Monitor which match the given labels. When no selector
+	// is given, the window applies to all of them.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// RolloutPolicy describes what happens with the checks of a Monitor while
+// one of the workloads serving its targets is being rolled out. A workload
+// is being rolled out while its controller hasn't observed its latest
+// generation yet, or while some of its replicas aren't available. The checks
+// are restored once the rollout is done.
+type RolloutPolicy struct {
+	// Action is either `Pause`, which pauses the checks with the provider,
+	// or `RaiseConfirmations`, which raises the number of confirmations the
+	// checks need before they alert.
+	Action string `json:"action"`
+
+	// Confirmations is the number of confirmations used during a rollout
+	// with the `RaiseConfirmations` action. It's required for that action.
+	// +optional
+	Confirmations *int `json:"confirmations,omitempty"`
+}
+
+// MonitorStatus describes the status of a Monitor and the resources it
+// references.
+type MonitorStatus struct {
+	// ObservedGeneration is the most recent generation of the Monitor which
+	// has been synced.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Ingresses is the number of Ingresses selected by the Monitor.
+	Ingresses int32 `json:"ingresses"`
+
+	// Services is the number of Services of type LoadBalancer selected by the
+	// Monitor.
+	// +optional
+	Services int32 `json:"services,omitempty"`
+
+	// HTTPRoutes is the number of HTTPRoutes selected by the Monitor.
+	// +optional
+	HTTPRoutes int32 `json:"httpRoutes,omitempty"`
+
+	// Hosts is the number of hosts across the selected Ingresses, Services
+	// and HTTPRoutes. Every host gets its own IngressMonitor.
+	Hosts int32 `json:"hosts"`
+
+	// IngressMonitors lists the names of the IngressMonitors managed by the
+	// Monitor.
+	// +optional
+	IngressMonitors []string `json:"ingressMonitors,omitempty"`
+
+	// ReadyIngressMonitors is the number of IngressMonitors managed by the
+	// Monitor which are Ready.
+	ReadyIngressMonitors int32 `json:"readyIngressMonitors"`
+
+	// ActiveMaintenanceWindows lists the names of the maintenance windows of
+	// the Monitor which are currently active.
+	// +optional
+	ActiveMaintenanceWindows []string `json:"activeMaintenanceWindows,omitempty"`
+
+	// PausedIngressMonitors is the number of IngressMonitors managed by the
+	// Monitor which are paused with the provider.
+	// +optional
+	PausedIngressMonitors int32 `json:"pausedIngressMonitors,omitempty"`
+
+	// Rollouts lists the Deployments and StatefulSets serving the targets of
+	// the Monitor which are currently being rolled out, like
+	// `Deployment/api`. It's only set when the Monitor has a rollout policy.
+	// +optional
+	Rollouts []string `json:"rollouts,omitempty"`
+
+	// Conditions describes the observed state of the Monitor.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -29,7 +194,8 @@ type Monitor struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata"`
 
-	Spec MonitorSpec `json:"spec"`
+	Spec   MonitorSpec   `json:"spec"`
+	Status MonitorStatus `json:"status"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
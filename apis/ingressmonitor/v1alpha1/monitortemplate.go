@@ -26,9 +26,44 @@ type MonitorTemplateSpec struct {
 	// +optional
 	Timeout *string `json:"timeout,omitempty"`
 
+	// Locations describes where the check runs from. Defaults to the
+	// provider's default.
+	// +optional
+	Locations *LocationTemplate `json:"locations,omitempty"`
+
 	// HTTP is the template for a HTTP Check. This is required when the type is
 	// set to `HTTP`.
 	HTTP *HTTPTemplate `json:"http,omitempty"`
+
+	// TCP is the template for a TCP Check. This is used when the type is set
+	// to `TCP`.
+	// +optional
+	TCP *TCPTemplate `json:"tcp,omitempty"`
+
+	// Certificate is the template for a Check of the expiry of a TLS
+	// certificate. This is used when the type is set to `Certificate`.
+	// +optional
+	Certificate *CertificateTemplate `json:"certificate,omitempty"`
+
+	// DNS is the template for a Check of the DNS records of a host. This is
+	// used when the type is set to `DNS`.
+	// +optional
+	DNS *DNSTemplate `json:"dns,omitempty"`
+}
+
+// LocationTemplate describes where a Check runs from.
+type LocationTemplate struct {
+	// Regions describes the regions the check runs from, one of `europe`,
+	// `north-america`, `south-america`, `asia`, `oceania` or `africa`.
+	// Every provider maps the regions to its own locations.
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+
+	// MinFailing describes how many locations should fail before an alert is
+	// sent, for providers which support it. Defaults to the provider's
+	// default.
+	// +optional
+	MinFailing *int `json:"minFailing,omitempty"`
 }
 
 // HTTPTemplate describes the configuration options for a HTTP Check.
@@ -70,6 +105,134 @@ type HTTPTemplate struct {
 	// FollowRedirects specifies if the check should follow redirects or not.
 	// +optional
 	FollowRedirects bool `json:"followRedirects,omitempty"`
+
+	// ExpectedStatusCodes describes the status codes which shouldn't trigger
+	// an alert, like a 401 for an endpoint which requires authentication.
+	// +optional
+	ExpectedStatusCodes []int `json:"expectedStatusCodes,omitempty"`
+
+	// AlertStatusCodes describes the status codes which should trigger an
+	// alert. Defaults to the provider's default.
+	// +optional
+	AlertStatusCodes []int `json:"alertStatusCodes,omitempty"`
+
+	// Method describes the HTTP method of the check request. Defaults to
+	// `GET`.
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Body describes the body which will be sent along with the check
+	// request. Defaults to ``.
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// Headers describes the headers which will be sent along with the check
+	// request.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// BasicAuth describes the credentials which are used to authenticate
+	// the check request with basic authentication.
+	// +optional
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+
+	// BearerToken describes the token which is sent in the Authorization
+	// header of the check request.
+	// +optional
+	BearerToken *SecretVar `json:"bearerToken,omitempty"`
+
+	// Assertions describes the checks the response should pass. Providers
+	// report the assertions they can't express in the status of the
+	// IngressMonitor.
+	// +optional
+	Assertions []Assertion `json:"assertions,omitempty"`
+}
+
+// Assertion describes a single check on the response of a HTTP Check.
+// Exactly one of its fields should be set.
+type Assertion struct {
+	// Regex describes a regular expression the response body should match.
+	// +optional
+	Regex string `json:"regex,omitempty"`
+
+	// JSONPath describes a value in the JSON response body.
+	// +optional
+	JSONPath *JSONPathAssertion `json:"jsonPath,omitempty"`
+
+	// Header describes a header of the response.
+	// +optional
+	Header *HeaderAssertion `json:"header,omitempty"`
+
+	// MaxResponseTime describes the duration in which the response should be
+	// received, like `2s`.
+	// +optional
+	MaxResponseTime string `json:"maxResponseTime,omitempty"`
+}
+
+// JSONPathAssertion asserts that a value in the JSON response body equals
+// the expected value.
+type JSONPathAssertion struct {
+	// Path is the JSON path of the value, like `$.status`.
+	Path string `json:"path"`
+
+	// Equals is the expected value.
+	Equals string `json:"equals"`
+}
+
+// HeaderAssertion asserts that a header is present in the response, and
+// optionally that it has the expected value.
+type HeaderAssertion struct {
+	// Name is the name of the header.
+	Name string `json:"name"`
+
+	// Equals is the expected value of the header. When it's empty, the header
+	// only has to be present.
+	// +optional
+	Equals string `json:"equals,omitempty"`
+}
+
+// BasicAuth describes the credentials for basic authentication. Secrets are
+// looked up in the namespace of the IngressMonitor.
+type BasicAuth struct {
+	// Username is the username used to authenticate the check request.
+	Username SecretVar `json:"username"`
+
+	// Password is the password used to authenticate the check request.
+	Password SecretVar `json:"password"`
+}
+
+// TCPTemplate describes the configuration options for a TCP Check.
+type TCPTemplate struct {
+	// Host describes the host which will be checked. Defaults to the host of
+	// the selected Ingress, Service or HTTPRoute.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Port describes the port which will be checked. Defaults to the port of
+	// the selected Service, or to 80 or 443 for Ingresses and HTTPRoutes.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
+// CertificateTemplate describes the configuration options for a Check of the
+// expiry of a TLS certificate.
+type CertificateTemplate struct {
+	// Host describes the host of which the certificate will be checked.
+	// Defaults to the TLS hosts of the selected Ingress.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// SecretName describes the Secret which holds the certificate of the host.
+	// The Operator checks this certificate itself when the provider doesn't
+	// support certificate checks. Defaults to the TLS Secret of the selected
+	// Ingress.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// WarningThresholds describes how long before the certificate expires
+	// alerts should be sent. Defaults to `["720h", "168h", "24h"]`.
+	// +optional
+	WarningThresholds []string `json:"warningThresholds,omitempty"`
 }
 
 // +genclient
@@ -93,3 +256,22 @@ type MonitorTemplateList struct {
 	metav1.ListMeta `json:"metadata"`
 	Items           []MonitorTemplate `json:"items"`
 }
+
+// DNSTemplate describes the configuration options for a DNS Check.
+type DNSTemplate struct {
+	// Host describes the host which should resolve to the expected records.
+	// Defaults to the hosts of the selected Ingress.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// ExpectedRecords describes the IP addresses or hostnames the host should
+	// resolve to. Defaults to the load balancer addresses in the status of
+	// the selected Ingress.
+	// +optional
+	ExpectedRecords []string `json:"expectedRecords,omitempty"`
+
+	// Server describes the DNS server which should be queried. Defaults to
+	// the DNS server of the configured provider.
+	// +optional
+	Server string `json:"server,omitempty"`
+}
@@ -10,6 +10,11 @@ type ProviderSpec struct {
 	// Type describes the type of Provider which this CRD will configure.
 	Type string `json:"type"`
 
+	// Timeout describes how long a single call to the provider may take
+	// before it's cancelled and retried. Defaults to `30s`.
+	// +optional
+	Timeout *string `json:"timeout,omitempty"`
+
 	// StatusCake describes the StatusCake Monitoring Provider
 	// +optional
 	StatusCake *StatusCakeProvider `json:"statusCake,omitempty"`
@@ -13,12 +13,16 @@ import (
 type Metrics struct {
 	Server
 	*prometheus.Registry
+
+	// Leader reports whether or not this replica is the leader. When it's
+	// set, the health check reports the leadership of the replica.
+	Leader func() bool
 }
 
 // Start starts the Health Server.
 func (s *Metrics) Start(stopCh <-chan struct{}) error {
 	registerMetrics(&s.ServeMux, s.Registry)
-	registerHealthCheck(&s.ServeMux)
+	registerHealthCheck(&s.ServeMux, s.Leader)
 
 	return s.Server.Start(stopCh)
 }
@@ -27,9 +31,15 @@ func registerMetrics(mux *http.ServeMux, reg *prometheus.Registry) {
 	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
 }
 
-func registerHealthCheck(mux *http.ServeMux) {
+// registerHealthCheck sets up the health check. Followers are as healthy as
+// the leader, so they report OK as well and only differ in the leadership
+// they report.
+func registerHealthCheck(mux *http.ServeMux, leader func() bool) {
 	mux.HandleFunc("/_healthz", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintln(w, "OK")
+		if leader != nil {
+			fmt.Fprintf(w, "leader: %t\n", leader())
+		}
 	})
 }
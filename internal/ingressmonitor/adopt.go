@@ -0,0 +1,69 @@
+package ingressmonitor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+
+	"github.com/sirupsen/logrus"
+)
+
+// adoptableMonitor looks for a monitor with the provider which checks the
+// same target under the same name as the given spec, and which isn't linked to
+// another IngressMonitor yet. This is the case when a monitor has been created
+// but its ID never made it into the status, e.g. because updating the status
+// failed. The ID of the monitor is returned, or an empty string when there's
+// none to adopt.
+func (o *Operator) adoptableMonitor(ctx context.Context, cl provider.Interface, obj *v1alpha1.IngressMonitor, spec v1alpha1.MonitorTemplateSpec) string {
+	monitors, err := cl.List(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ingress_monitor_namespace": obj.Namespace,
+			"ingress_monitor_name":      obj.Name,
+		}).WithError(err).Warn("Could not list the monitors of the provider to adopt one")
+		return ""
+	}
+
+	linked := map[string]bool{}
+	for _, item := range o.imInformer.GetIndexer().List() {
+		im := item.(*v1alpha1.IngressMonitor)
+		if im.Spec.Provider.Type == obj.Spec.Provider.Type && im.Status.ID != "" {
+			linked[im.Status.ID] = true
+		}
+	}
+
+	key := monitorKey(spec)
+	for _, mon := range monitors {
+		if !linked[mon.ID] && monitorKey(mon.Spec) == key {
+			return mon.ID
+		}
+	}
+
+	return ""
+}
+
+// monitorKey identifies a monitor by its type, name and the target it
+// checks. Providers name certificate checks after their host, so their name
+// isn't used.
+func monitorKey(spec v1alpha1.MonitorTemplateSpec) string {
+	tp := spec.Type
+	if tp == "" {
+		tp = "HTTP"
+	}
+
+	var target string
+	switch {
+	case tp == "TCP" && spec.TCP != nil:
+		target = fmt.Sprintf("%s:%d", spec.TCP.Host, spec.TCP.Port)
+	case tp == "DNS" && spec.DNS != nil:
+		target = spec.DNS.Host
+	case tp == "Certificate" && spec.Certificate != nil:
+		return fmt.Sprintf("%s/%s", tp, spec.Certificate.Host)
+	case tp == "HTTP" && spec.HTTP != nil:
+		target = spec.HTTP.URL
+	}
+
+	return fmt.Sprintf("%s/%s/%s", tp, spec.Name, target)
+}
@@ -0,0 +1,87 @@
+package ingressmonitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOperator_AdoptMonitor(t *testing.T) {
+	newAdoptableMonitor := func() *v1alpha1.IngressMonitor {
+		im := newIngressMonitor()
+		im.Spec.Template = v1alpha1.MonitorTemplateSpec{
+			Name: "go-ingress",
+			HTTP: &v1alpha1.HTTPTemplate{URL: "https://example.com/_healthz"},
+		}
+		return im
+	}
+
+	newListingProvider := func() *fake.SimpleProvider {
+		return &fake.SimpleProvider{
+			CreateFunc: func(context.Context, v1alpha1.MonitorTemplateSpec) (string, error) {
+				return "67890", nil
+			},
+			UpdateFunc: func(_ context.Context, id string, _ v1alpha1.MonitorTemplateSpec) (string, error) {
+				return id, provider.ErrNoChange
+			},
+			ListFunc: func(context.Context) ([]provider.Monitor, error) {
+				return []provider.Monitor{
+					{ID: "11111", Spec: v1alpha1.MonitorTemplateSpec{Name: "go-ingress", Type: "HTTP", HTTP: &v1alpha1.HTTPTemplate{URL: "https://example.com"}}},
+					{ID: "12345", Spec: v1alpha1.MonitorTemplateSpec{Name: "go-ingress", Type: "HTTP", HTTP: &v1alpha1.HTTPTemplate{URL: "https://example.com/_healthz"}}},
+				}, nil
+			},
+		}
+	}
+
+	t.Run("with a matching monitor", func(t *testing.T) {
+		op := newOperator(t)
+		prov := newListingProvider()
+		op.op.providerFactory.Register("simple", fake.FactoryFunc(prov))
+
+		im := newAdoptableMonitor()
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		strEquals(t, "12345", im.Status.ID, "provider ID")
+		eventsEqual(t, op, "Normal Adopted Adopted existing monitor 12345 with provider simple")
+
+		if prov.CreateCount != 0 || prov.UpdateCount != 1 {
+			t.Errorf("Expected the monitor to be updated instead of created, got %d creates and %d updates", prov.CreateCount, prov.UpdateCount)
+		}
+
+		// Once it's synced, the monitors aren't listed anymore.
+		im.Status.ID = ""
+		op.op.imInformer.GetIndexer().Update(im)
+		errEquals(t, nil, op.op.handleIngressMonitor(context.TODO(), getKey(t, im)))
+
+		if prov.ListCount != 1 {
+			t.Errorf("Expected the monitors to be listed once, got %d", prov.ListCount)
+		}
+	})
+
+	t.Run("with a monitor linked to another IngressMonitor", func(t *testing.T) {
+		other := newAdoptableMonitor()
+		other.Name = "other-im"
+		other.Status.ID = "12345"
+
+		op := newOperator(t, withIngressMonitors(other))
+		prov := newListingProvider()
+		op.op.providerFactory.Register("simple", fake.FactoryFunc(prov))
+
+		im := newAdoptableMonitor()
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		strEquals(t, "67890", im.Status.ID, "provider ID")
+		eventsEqual(t, op, "Normal Created Created monitor 67890 with provider simple")
+	})
+}
@@ -0,0 +1,159 @@
+package ingressmonitor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The annotations below can be set on an Ingress, Service or HTTPRoute to
+// override the check settings of the MonitorTemplate for that resource only.
+const (
+	disabledAnnotation         = "ingressmonitor.sphc.io/disabled"
+	pausedAnnotation           = "ingressmonitor.sphc.io/paused"
+	endpointAnnotation         = "ingressmonitor.sphc.io/endpoint"
+	shouldContainAnnotation    = "ingressmonitor.sphc.io/should-contain"
+	shouldNotContainAnnotation = "ingressmonitor.sphc.io/should-not-contain"
+	checkRateAnnotation        = "ingressmonitor.sphc.io/check-rate"
+	timeoutAnnotation          = "ingressmonitor.sphc.io/timeout"
+	confirmationsAnnotation    = "ingressmonitor.sphc.io/confirmations"
+	contactGroupsAnnotation    = "ingressmonitor.sphc.io/contact-groups"
+)
+
+// annotationError describes an annotation which has been set to a value that
+// can't be used.
+type annotationError struct {
+	key   string
+	value string
+	err   error
+}
+
+func (e annotationError) Error() string {
+	return fmt.Sprintf("invalid value %q for %s: %s", e.value, e.key, e.err)
+}
+
+// invalidAnnotation links an invalid annotation on the owner of a target to
+// the IngressMonitor of that target, which is used to record its Events.
+type invalidAnnotation struct {
+	im  *v1alpha1.IngressMonitor
+	err error
+}
+
+// isDisabled reports if monitoring has been disabled for the given object
+// through the disabled annotation. Invalid values don't disable monitoring,
+// they're reported by applyAnnotations instead.
+func isDisabled(obj metav1.Object) bool {
+	disabled, err := strconv.ParseBool(obj.GetAnnotations()[disabledAnnotation])
+	return err == nil && disabled
+}
+
+// hasPausedAnnotation reports if the checks for the given object have been
+// paused through the paused annotation. Besides Ingresses, Services and
+// HTTPRoutes, it can be set on Monitors and IngressMonitors.
+func hasPausedAnnotation(obj metav1.Object) bool {
+	paused, err := strconv.ParseBool(obj.GetAnnotations()[pausedAnnotation])
+	return err == nil && paused
+}
+
+// applyAnnotations merges the check settings configured through annotations on
+// the given object over the template and provider specs. Annotations with an
+// invalid value are skipped, so the value of the MonitorTemplate or Provider is
+// used instead. An error is returned for every annotation which is skipped.
+func applyAnnotations(obj metav1.Object, tmpl *v1alpha1.MonitorTemplateSpec, prov *v1alpha1.ProviderSpec) []error {
+	annotations := obj.GetAnnotations()
+
+	var errs []error
+	invalid := func(key string, err error) {
+		errs = append(errs, annotationError{key: key, value: annotations[key], err: err})
+	}
+
+	for _, key := range []string{disabledAnnotation, pausedAnnotation} {
+		if val, ok := annotations[key]; ok {
+			if _, err := strconv.ParseBool(val); err != nil {
+				invalid(key, fmt.Errorf("must be true or false"))
+			}
+		}
+	}
+
+	if val, ok := annotations[endpointAnnotation]; ok {
+		if !strings.HasPrefix(val, "/") {
+			invalid(endpointAnnotation, fmt.Errorf("must start with a /"))
+		} else {
+			httpTemplate(tmpl).Endpoint = &val
+		}
+	}
+
+	if val, ok := annotations[shouldContainAnnotation]; ok {
+		httpTemplate(tmpl).ShouldContain = val
+	}
+
+	if val, ok := annotations[shouldNotContainAnnotation]; ok {
+		httpTemplate(tmpl).ShouldNotContain = val
+	}
+
+	for _, d := range []struct {
+		key   string
+		field **string
+	}{
+		{checkRateAnnotation, &tmpl.CheckRate},
+		{timeoutAnnotation, &tmpl.Timeout},
+	} {
+		val, ok := annotations[d.key]
+		if !ok {
+			continue
+		}
+
+		if dur, err := time.ParseDuration(val); err != nil {
+			invalid(d.key, err)
+		} else if dur <= 0 {
+			invalid(d.key, fmt.Errorf("must be a positive duration"))
+		} else {
+			*d.field = &val
+		}
+	}
+
+	if val, ok := annotations[confirmationsAnnotation]; ok {
+		if i, err := strconv.Atoi(val); err != nil || i < 0 {
+			invalid(confirmationsAnnotation, fmt.Errorf("must be a non-negative number"))
+		} else {
+			tmpl.Confirmations = &i
+		}
+	}
+
+	if val, ok := annotations[contactGroupsAnnotation]; ok {
+		if prov.StatusCake == nil {
+			invalid(contactGroupsAnnotation, fmt.Errorf("contact groups aren't supported by provider %s", prov.Type))
+		} else {
+			prov.StatusCake.ContactGroups = splitList(val)
+		}
+	}
+
+	return errs
+}
+
+// httpTemplate returns the HTTP template of the given spec, setting it up when
+// the MonitorTemplate doesn't configure it.
+func httpTemplate(tmpl *v1alpha1.MonitorTemplateSpec) *v1alpha1.HTTPTemplate {
+	if tmpl.HTTP == nil {
+		tmpl.HTTP = &v1alpha1.HTTPTemplate{}
+	}
+
+	return tmpl.HTTP
+}
+
+// splitList splits a comma separated list, dropping empty items.
+func splitList(s string) []string {
+	list := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
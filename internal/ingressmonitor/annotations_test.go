@@ -0,0 +1,100 @@
+package ingressmonitor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+)
+
+func TestApplyAnnotations(t *testing.T) {
+	t.Run("with valid annotations", func(t *testing.T) {
+		ing := newIngress()
+		ing.Annotations = map[string]string{
+			endpointAnnotation:         "/status",
+			shouldContainAnnotation:    "OK",
+			shouldNotContainAnnotation: "error",
+			checkRateAnnotation:        "1m",
+			timeoutAnnotation:          "10s",
+			confirmationsAnnotation:    "3",
+			contactGroupsAnnotation:    "123, 456,",
+		}
+
+		tmpl := newTemplate().Spec
+		prov := v1alpha1.ProviderSpec{Type: "StatusCake", StatusCake: &v1alpha1.StatusCakeProvider{}}
+
+		errs := applyAnnotations(ing, &tmpl, &prov)
+		if len(errs) != 0 {
+			t.Fatalf("Expected no errors, got %v", errs)
+		}
+
+		strEquals(t, "/status", *tmpl.HTTP.Endpoint, "endpoint")
+		strEquals(t, "OK", tmpl.HTTP.ShouldContain, "should contain")
+		strEquals(t, "error", tmpl.HTTP.ShouldNotContain, "should not contain")
+		strEquals(t, "1m", *tmpl.CheckRate, "check rate")
+		strEquals(t, "10s", *tmpl.Timeout, "timeout")
+
+		if *tmpl.Confirmations != 3 {
+			t.Errorf("Expected confirmations to be 3, got %d", *tmpl.Confirmations)
+		}
+
+		if !reflect.DeepEqual([]string{"123", "456"}, prov.StatusCake.ContactGroups) {
+			t.Errorf("Expected contact groups to be overridden, got %v", prov.StatusCake.ContactGroups)
+		}
+	})
+
+	t.Run("with invalid annotations", func(t *testing.T) {
+		ing := newIngress()
+		ing.Annotations = map[string]string{
+			disabledAnnotation:      "maybe",
+			pausedAnnotation:        "sometimes",
+			endpointAnnotation:      "status",
+			checkRateAnnotation:     "-1m",
+			timeoutAnnotation:       "soon",
+			confirmationsAnnotation: "three",
+			contactGroupsAnnotation: "123",
+		}
+
+		tmpl := newTemplate().Spec
+		prov := v1alpha1.ProviderSpec{Type: "simple"}
+
+		errs := applyAnnotations(ing, &tmpl, &prov)
+		if len(errs) != 7 {
+			t.Fatalf("Expected 7 errors, got %v", errs)
+		}
+
+		strEquals(t, `invalid value "maybe" for ingressmonitor.sphc.io/disabled: must be true or false`, errs[0].Error())
+		strEquals(t, `invalid value "sometimes" for ingressmonitor.sphc.io/paused: must be true or false`, errs[1].Error())
+		strEquals(t, `invalid value "status" for ingressmonitor.sphc.io/endpoint: must start with a /`, errs[2].Error())
+		strEquals(t, `invalid value "three" for ingressmonitor.sphc.io/confirmations: must be a non-negative number`, errs[5].Error())
+		strEquals(t, `invalid value "123" for ingressmonitor.sphc.io/contact-groups: contact groups aren't supported by provider simple`, errs[6].Error())
+
+		strEquals(t, "/test-healthz", *tmpl.HTTP.Endpoint, "endpoint")
+		if tmpl.CheckRate != nil || tmpl.Timeout != nil || tmpl.Confirmations != nil {
+			t.Errorf("Expected the template values to be kept, got %#v", tmpl)
+		}
+	})
+}
+
+func TestIsDisabled(t *testing.T) {
+	tcs := []struct {
+		value    string
+		disabled bool
+	}{
+		{"", false},
+		{"true", true},
+		{"false", false},
+		{"maybe", false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.value, func(t *testing.T) {
+			ing := newIngress()
+			ing.Annotations = map[string]string{disabledAnnotation: tc.value}
+
+			if isDisabled(ing) != tc.disabled {
+				t.Errorf("Expected disabled to be %t", tc.disabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package ingressmonitor
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// canCheckCertificate reports if the Operator can check the certificate of
+// the given IngressMonitor itself, which is the case for certificate checks
+// with a TLS Secret.
+func canCheckCertificate(obj *v1alpha1.IngressMonitor) bool {
+	cert := obj.Spec.Template.Certificate
+	return obj.Spec.Template.Type == "Certificate" && cert != nil && cert.SecretName != ""
+}
+
+// checkCertificate checks the certificate in the TLS Secret of a certificate
+// check which isn't supported by the provider, and records the outcome in the
+// status of the IngressMonitor. A Warning Event is recorded every time the
+// certificate passes one of the warning thresholds.
+func (o *Operator) checkCertificate(ctx context.Context, obj *v1alpha1.IngressMonitor, providerErr error, credsCondition v1alpha1.Condition) error {
+	cert := obj.Spec.Template.Certificate
+
+	thresholds, err := provider.CertificateThresholds(cert)
+	if err != nil {
+		err = provider.InvalidSpec(err)
+		return o.recordIngressMonitorSync(ctx, obj, err,
+			credsCondition,
+			newCondition(v1alpha1.ConditionProviderSynced, false, reasonInvalidSpec, err.Error()),
+		)
+	}
+
+	synced := newCondition(v1alpha1.ConditionProviderSynced, true, reasonCertificateFromSecret,
+		fmt.Sprintf("%s, checking the certificate in Secret %s instead", providerErr, cert.SecretName),
+	)
+
+	notAfter, err := o.certificateExpiry(ctx, obj.Namespace, cert.SecretName)
+	if err != nil {
+		return o.recordIngressMonitorSync(ctx, obj, err,
+			credsCondition,
+			synced,
+			newCondition(v1alpha1.ConditionCertificateValid, false, reasonCertificateNotFound, err.Error()),
+		)
+	}
+
+	obj.Status.Certificate = &v1alpha1.CertificateStatus{
+		SecretName: cert.SecretName,
+		NotAfter:   metav1.NewTime(notAfter),
+	}
+
+	expiry := notAfter.UTC().Format(time.RFC3339)
+	valid := newCondition(v1alpha1.ConditionCertificateValid, true, reasonCertificateValid, fmt.Sprintf("Certificate expires at %s", expiry))
+	if remaining := time.Until(notAfter); remaining <= 0 {
+		valid = newCondition(v1alpha1.ConditionCertificateValid, false, reasonCertificateExpired, fmt.Sprintf("Certificate expired at %s", expiry))
+	} else {
+		// The thresholds are sorted from the longest to the shortest, so
+		// the last one which has been passed is the most urgent one.
+		for _, threshold := range thresholds {
+			if remaining <= threshold {
+				valid = newCondition(v1alpha1.ConditionCertificateValid, false, reasonCertificateExpiring,
+					fmt.Sprintf("Certificate expires within %s, at %s", threshold, expiry),
+				)
+			}
+		}
+	}
+
+	prev := getCondition(obj.Status.Conditions, v1alpha1.ConditionCertificateValid)
+	if valid.Status == v1.ConditionFalse && (prev == nil || prev.Message != valid.Message) {
+		o.recordEvent(obj, v1.EventTypeWarning, eventReasonCertificateExpiring, "Certificate for %s in Secret %s: %s", cert.Host, cert.SecretName, valid.Message)
+	}
+
+	return o.recordIngressMonitorSync(ctx, obj, nil, credsCondition, synced, valid)
+}
+
+// certificateExpiry returns the time at which the certificate in the given TLS
+// Secret expires. The first certificate in the Secret is the certificate of
+// the host, the others make up its chain.
+func (o *Operator) certificateExpiry(ctx context.Context, namespace, name string) (time.Time, error) {
+	secret, err := o.kubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Could not get Secret %s: %s", name, err)
+	}
+
+	block, _ := pem.Decode(secret.Data[v1.TLSCertKey])
+	if block == nil || block.Type != "CERTIFICATE" {
+		return time.Time{}, fmt.Errorf("Secret %s doesn't contain a certificate", name)
+	}
+
+	crt, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Could not parse the certificate in Secret %s: %s", name, err)
+	}
+
+	return crt.NotAfter, nil
+}
@@ -0,0 +1,128 @@
+package ingressmonitor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider/fake"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOperator_CheckCertificate(t *testing.T) {
+	unsupported := &provider.UnsupportedTypeError{Provider: "Fake", Type: "Certificate"}
+
+	newCertificateMonitor := func() *v1alpha1.IngressMonitor {
+		im := newIngressMonitor()
+		im.Spec.Template.Type = "Certificate"
+		im.Spec.Template.HTTP = nil
+		im.Spec.Template.Certificate = &v1alpha1.CertificateTemplate{
+			Host:       "api.example.com",
+			SecretName: "api-tls",
+		}
+		return im
+	}
+
+	newUnsupportedOperator := func(opts ...optionFunc) *operatorWrapper {
+		op := newOperator(t, opts...)
+		prov := &fake.SimpleProvider{
+			CreateFunc: func(context.Context, v1alpha1.MonitorTemplateSpec) (string, error) {
+				return "", unsupported
+			},
+		}
+		op.op.providerFactory.Register("simple", fake.FactoryFunc(prov))
+		return op
+	}
+
+	t.Run("with an expiring certificate", func(t *testing.T) {
+		notAfter := time.Now().Add(5 * 24 * time.Hour).UTC().Truncate(time.Second)
+		op := newUnsupportedOperator(withSecrets(newTLSSecret(t, notAfter)))
+
+		im := newCertificateMonitor()
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionProviderSynced, v1.ConditionTrue, reasonCertificateFromSecret)
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionCertificateValid, v1.ConditionFalse, reasonCertificateExpiring)
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionTrue, reasonSynced)
+
+		if im.Status.Certificate == nil || !im.Status.Certificate.NotAfter.Time.Equal(notAfter) {
+			t.Errorf("Expected the certificate to expire at %s, got %#v", notAfter, im.Status.Certificate)
+		}
+
+		eventsEqual(t, op, fmt.Sprintf(
+			"Warning CertificateExpiring Certificate for api.example.com in Secret api-tls: Certificate expires within 168h0m0s, at %s",
+			notAfter.Format(time.RFC3339),
+		))
+	})
+
+	t.Run("with a valid certificate", func(t *testing.T) {
+		op := newUnsupportedOperator(withSecrets(newTLSSecret(t, time.Now().Add(90*24*time.Hour))))
+
+		im := newCertificateMonitor()
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionCertificateValid, v1.ConditionTrue, reasonCertificateValid)
+		eventsEqual(t, op)
+	})
+
+	t.Run("without the TLS Secret", func(t *testing.T) {
+		op := newUnsupportedOperator()
+
+		im := newCertificateMonitor()
+		if err := op.handleIngressMonitor(t, im); err == nil {
+			t.Errorf("Expected an error for the missing Secret")
+		}
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionCertificateValid, v1.ConditionFalse, reasonCertificateNotFound)
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionFalse, reasonCertificateNotFound)
+	})
+}
+
+// newTLSSecret returns a TLS Secret named api-tls with a self-signed
+// certificate which expires at the given time.
+func newTLSSecret(t *testing.T, notAfter time.Time) *v1.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	errEquals(t, nil, err, "generating a key")
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "api.example.com"},
+		DNSNames:     []string{"api.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	errEquals(t, nil, err, "creating a certificate")
+
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-tls",
+			Namespace: "testing",
+		},
+		Type: v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			v1.TLSCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		},
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/spf13/cobra"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -30,6 +31,16 @@ var operatorFlags struct {
 
 	MetricsAddr string
 	MetricsPort int
+
+	WatchRollouts bool
+
+	LeaderElect              bool
+	LeaderElectNamespace     string
+	LeaderElectLeaseName     string
+	LeaderElectIdentity      string
+	LeaderElectLeaseDuration string
+	LeaderElectRenewDeadline string
+	LeaderElectRetryPeriod   string
 }
 
 // operatorCmd represents the operator command
@@ -62,6 +73,11 @@ func runOperator(cmd *cobra.Command, args []string) {
 		logrus.WithError(err).Fatal("Error building IngressMonitor clientset")
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		logrus.WithError(err).Fatal("Error building dynamic Kubernetes client")
+	}
+
 	// register the available providers
 	fact := provider.NewFactory(kubeClient)
 	statuscake.Register(fact)
@@ -69,11 +85,24 @@ func runOperator(cmd *cobra.Command, args []string) {
 
 	// create new prometheus registry
 	registry := prometheus.NewRegistry()
-	registry.MustRegister(prometheus.NewProcessCollector(os.Getpid(), ""))
+	registry.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
 	registry.MustRegister(prometheus.NewGoCollector())
 
 	// new metrics collector
 	mtrc := metrics.New(registry)
+
+	op, err := ingressmonitor.NewOperator(
+		kubeClient, imClient, dynamicClient, operatorFlags.Namespace,
+		resync, fact, mtrc,
+	)
+	if err != nil {
+		logrus.WithError(err).Fatalf("Error building IngressMonitor Operator")
+	}
+
+	if operatorFlags.WatchRollouts {
+		op.WatchRollouts(resync)
+	}
+
 	metricssvc := httpsvc.Metrics{
 		Server: httpsvc.Server{
 			Addr: operatorFlags.MetricsAddr,
@@ -81,21 +110,63 @@ func runOperator(cmd *cobra.Command, args []string) {
 		},
 		Registry: registry,
 	}
+	if operatorFlags.LeaderElect {
+		metricssvc.Leader = op.IsLeader
+	}
 	go metricssvc.Start(stopCh)
 
-	op, err := ingressmonitor.NewOperator(
-		kubeClient, imClient, operatorFlags.Namespace,
-		resync, fact, mtrc,
-	)
-	if err != nil {
-		logrus.WithError(err).Fatalf("Error building IngressMonitor Operator")
+	if !operatorFlags.LeaderElect {
+		if err := op.Run(stopCh); err != nil {
+			logrus.WithError(err).Fatalf("Error running the operator")
+		}
+		return
 	}
 
-	if err := op.Run(stopCh); err != nil {
+	// A replica which loses the Lease exits, so it's restarted as a follower
+	// without any syncs in flight.
+	if err := op.RunWithLeaderElection(stopCh, leaderElectionConfig()); err != nil {
 		logrus.WithError(err).Fatalf("Error running the operator")
 	}
 }
 
+// leaderElectionConfig builds the leader election configuration from the
+// flags. The identity defaults to the hostname, which is the name of the pod
+// when running in a cluster.
+func leaderElectionConfig() ingressmonitor.LeaderElectionConfig {
+	identity := operatorFlags.LeaderElectIdentity
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			logrus.WithError(err).Fatal("Error getting the hostname for the leader election identity")
+		}
+		identity = hostname
+	}
+
+	cfg := ingressmonitor.LeaderElectionConfig{
+		Namespace: operatorFlags.LeaderElectNamespace,
+		Name:      operatorFlags.LeaderElectLeaseName,
+		Identity:  identity,
+	}
+
+	for _, d := range []struct {
+		name  string
+		value string
+		into  *time.Duration
+	}{
+		{"LeaderElectLeaseDuration", operatorFlags.LeaderElectLeaseDuration, &cfg.LeaseDuration},
+		{"LeaderElectRenewDeadline", operatorFlags.LeaderElectRenewDeadline, &cfg.RenewDeadline},
+		{"LeaderElectRetryPeriod", operatorFlags.LeaderElectRetryPeriod, &cfg.RetryPeriod},
+	} {
+		v, err := time.ParseDuration(d.value)
+		if err != nil {
+			logrus.WithError(err).Fatalf("Error parsing %s", d.name)
+		}
+		*d.into = v
+	}
+
+	return cfg
+}
+
 func init() {
 	rootCmd.AddCommand(operatorCmd)
 
@@ -106,4 +177,14 @@ func init() {
 
 	operatorCmd.PersistentFlags().StringVar(&operatorFlags.MetricsAddr, "metrics-addr", "0.0.0.0", "address the metrics server will bind to")
 	operatorCmd.PersistentFlags().IntVar(&operatorFlags.MetricsPort, "metrics-port", 9090, "port on which the metrics server is available")
+
+	operatorCmd.PersistentFlags().BoolVar(&operatorFlags.WatchRollouts, "watch-rollouts", false, "Watch Deployments and StatefulSets in the whole cluster so Monitors can follow their rollouts. Needs permission to list and watch them.")
+
+	operatorCmd.PersistentFlags().BoolVar(&operatorFlags.LeaderElect, "leader-elect", false, "Elect a leader through a Lease so multiple replicas can run. Only the leader syncs monitors.")
+	operatorCmd.PersistentFlags().StringVar(&operatorFlags.LeaderElectNamespace, "leader-elect-namespace", "ingress-monitor", "The namespace of the Lease used for leader election.")
+	operatorCmd.PersistentFlags().StringVar(&operatorFlags.LeaderElectLeaseName, "leader-elect-lease-name", "ingress-monitor", "The name of the Lease used for leader election.")
+	operatorCmd.PersistentFlags().StringVar(&operatorFlags.LeaderElectIdentity, "leader-elect-identity", "", "The unique identity of this replica in the leader election. Defaults to the hostname.")
+	operatorCmd.PersistentFlags().StringVar(&operatorFlags.LeaderElectLeaseDuration, "leader-elect-lease-duration", "15s", "How long followers wait before taking over a Lease which hasn't been renewed.")
+	operatorCmd.PersistentFlags().StringVar(&operatorFlags.LeaderElectRenewDeadline, "leader-elect-renew-deadline", "10s", "How long the leader tries to renew the Lease before giving up its leadership.")
+	operatorCmd.PersistentFlags().StringVar(&operatorFlags.LeaderElectRetryPeriod, "leader-elect-retry-period", "2s", "How long to wait between attempts to acquire or renew the Lease.")
 }
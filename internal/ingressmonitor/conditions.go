@@ -0,0 +1,124 @@
+package ingressmonitor
+
+import (
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	reasonResolved            = "Resolved"
+	reasonProviderNotFound    = "ProviderNotFound"
+	reasonTemplateNotFound    = "TemplateNotFound"
+	reasonProviderUnavailable = "ProviderUnavailable"
+	reasonSynced              = "Synced"
+	reasonSyncFailed          = "SyncFailed"
+	reasonUnauthorized        = "Unauthorized"
+	reasonSecretNotFound      = "SecretNotFound"
+	reasonRateLimited         = "RateLimited"
+	reasonInvalidSpec         = "InvalidSpec"
+	reasonUnsupportedType     = "UnsupportedType"
+	reasonValidSelector       = "ValidSelector"
+	reasonInvalidSelector     = "InvalidSelector"
+	reasonValidAnnotations    = "ValidAnnotations"
+	reasonInvalidAnnotations  = "InvalidAnnotations"
+
+	reasonCertificateFromSecret = "CertificateFromSecret"
+	reasonCertificateValid      = "CertificateValid"
+	reasonCertificateExpiring   = "CertificateExpiring"
+	reasonCertificateExpired    = "CertificateExpired"
+	reasonCertificateNotFound   = "CertificateNotFound"
+
+	reasonAssertionsSupported   = "AssertionsSupported"
+	reasonUnsupportedAssertions = "UnsupportedAssertions"
+
+	reasonValidMaintenanceWindows   = "ValidMaintenanceWindows"
+	reasonInvalidMaintenanceWindows = "InvalidMaintenanceWindows"
+	reasonMaintenanceWindow         = "MaintenanceWindow"
+	reasonPausedByAnnotation        = "PausedByAnnotation"
+	reasonResumed                   = "Resumed"
+	reasonPauseUnsupported          = "PauseUnsupported"
+
+	reasonValidRolloutPolicy   = "ValidRolloutPolicy"
+	reasonInvalidRolloutPolicy = "InvalidRolloutPolicy"
+	reasonRolloutsNotWatched   = "RolloutsNotWatched"
+	reasonRollout              = "Rollout"
+
+	reasonIngressMonitorsNotReady = "IngressMonitorsNotReady"
+)
+
+// setCondition adds the given condition to the list of conditions or replaces
+// the existing condition of the same type. The LastTransitionTime is only
+// updated when the status of the condition changes.
+func setCondition(conds []v1alpha1.Condition, cond v1alpha1.Condition) []v1alpha1.Condition {
+	for i, c := range conds {
+		if c.Type != cond.Type {
+			continue
+		}
+
+		if c.Status == cond.Status {
+			cond.LastTransitionTime = c.LastTransitionTime
+		} else {
+			cond.LastTransitionTime = metav1.Now()
+		}
+
+		conds[i] = cond
+		return conds
+	}
+
+	cond.LastTransitionTime = metav1.Now()
+	return append(conds, cond)
+}
+
+// getCondition returns the condition of the given type, or nil if the
+// condition isn't present.
+func getCondition(conds []v1alpha1.Condition, tp v1alpha1.ConditionType) *v1alpha1.Condition {
+	for i := range conds {
+		if conds[i].Type == tp {
+			return &conds[i]
+		}
+	}
+
+	return nil
+}
+
+// isProviderSynced determines whether the IngressMonitor has been synced with
+// its provider.
+func isProviderSynced(conds []v1alpha1.Condition) bool {
+	cond := getCondition(conds, v1alpha1.ConditionProviderSynced)
+	return cond != nil && cond.Status == v1.ConditionTrue
+}
+
+// removeCondition removes the condition of the given type from the list of
+// conditions.
+func removeCondition(conds []v1alpha1.Condition, tp v1alpha1.ConditionType) []v1alpha1.Condition {
+	for i := range conds {
+		if conds[i].Type == tp {
+			return append(conds[:i], conds[i+1:]...)
+		}
+	}
+
+	return conds
+}
+
+func newCondition(tp v1alpha1.ConditionType, ok bool, reason, msg string) v1alpha1.Condition {
+	status := v1.ConditionFalse
+	if ok {
+		status = v1.ConditionTrue
+	}
+
+	return v1alpha1.Condition{
+		Type:    tp,
+		Status:  status,
+		Reason:  reason,
+		Message: msg,
+	}
+}
+
+// isReady reports if the Ready condition is set to True in the given list of
+// conditions.
+func isReady(conds []v1alpha1.Condition) bool {
+	cond := getCondition(conds, v1alpha1.ConditionReady)
+	return cond != nil && cond.Status == v1.ConditionTrue
+}
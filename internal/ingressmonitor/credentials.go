@@ -0,0 +1,66 @@
+package ingressmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// resolveTemplate returns a copy of the template of the IngressMonitor in
+// which the credentials of the check are replaced by their values, so
+// providers don't need access to the Secrets in the namespace of the
+// IngressMonitor.
+func (o *Operator) resolveTemplate(ctx context.Context, obj *v1alpha1.IngressMonitor) (v1alpha1.MonitorTemplateSpec, error) {
+	tmpl := *obj.Spec.Template.DeepCopy()
+
+	http := tmpl.HTTP
+	if http == nil {
+		return tmpl, nil
+	}
+
+	var vars []*v1alpha1.SecretVar
+	if http.BasicAuth != nil {
+		vars = append(vars, &http.BasicAuth.Username, &http.BasicAuth.Password)
+	}
+	if http.BearerToken != nil {
+		vars = append(vars, http.BearerToken)
+	}
+
+	for _, sv := range vars {
+		if err := o.resolveSecretVar(ctx, obj.Namespace, sv); err != nil {
+			return tmpl, err
+		}
+	}
+
+	return tmpl, nil
+}
+
+// resolveSecretVar replaces a reference to a Secret with the value it
+// references.
+func (o *Operator) resolveSecretVar(ctx context.Context, namespace string, sv *v1alpha1.SecretVar) error {
+	if sv.Value != nil {
+		return nil
+	}
+
+	if sv.ValueFrom == nil {
+		return errors.New("Credentials need either a value or a reference to a Secret")
+	}
+
+	secret, err := o.kubeClient.CoreV1().Secrets(namespace).Get(ctx, sv.ValueFrom.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Could not get Secret %s: %s", sv.ValueFrom.Name, err)
+	}
+
+	data, ok := secret.Data[sv.ValueFrom.Key]
+	if !ok {
+		return fmt.Errorf("Secret %s doesn't contain the key %s", sv.ValueFrom.Name, sv.ValueFrom.Key)
+	}
+
+	value := string(data)
+	sv.Value, sv.ValueFrom = &value, nil
+	return nil
+}
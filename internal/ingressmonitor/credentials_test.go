@@ -0,0 +1,92 @@
+package ingressmonitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider/fake"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOperator_ResolveCredentials(t *testing.T) {
+	newAuthMonitor := func() *v1alpha1.IngressMonitor {
+		im := newIngressMonitor()
+		im.Spec.Template.HTTP = &v1alpha1.HTTPTemplate{
+			URL: "https://api.example.com/_healthz",
+			BasicAuth: &v1alpha1.BasicAuth{
+				Username: v1alpha1.SecretVar{Value: ptrString("monitor")},
+				Password: v1alpha1.SecretVar{ValueFrom: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "health-auth"},
+					Key:                  "password",
+				}},
+			},
+			BearerToken: &v1alpha1.SecretVar{ValueFrom: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "health-auth"},
+				Key:                  "token",
+			}},
+		}
+		return im
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "health-auth",
+			Namespace: "testing",
+		},
+		Data: map[string][]byte{
+			"password": []byte("hunter2"),
+			"token":    []byte("s3cr3t"),
+		},
+	}
+
+	t.Run("with the Secret", func(t *testing.T) {
+		op := newOperator(t, withSecrets(secret))
+
+		var created v1alpha1.MonitorTemplateSpec
+		op.op.providerFactory.Register("simple", fake.FactoryFunc(&fake.SimpleProvider{
+			CreateFunc: func(_ context.Context, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+				created = tpl
+				return "12345", nil
+			},
+		}))
+
+		im := newAuthMonitor()
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+
+		auth := created.HTTP.BasicAuth
+		if auth.Username.Value == nil || *auth.Username.Value != "monitor" || auth.Password.Value == nil || *auth.Password.Value != "hunter2" {
+			t.Errorf("Expected the basic auth credentials to be resolved, got %#v", auth)
+		}
+
+		if token := created.HTTP.BearerToken; token.Value == nil || *token.Value != "s3cr3t" || token.ValueFrom != nil {
+			t.Errorf("Expected the bearer token to be resolved, got %#v", token)
+		}
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		// The resolved values never end up in the IngressMonitor itself.
+		if im.Spec.Template.HTTP.BasicAuth.Password.Value != nil {
+			t.Errorf("Expected the IngressMonitor to keep referencing the Secret")
+		}
+	})
+
+	t.Run("without the Secret", func(t *testing.T) {
+		op := newOperator(t)
+		op.op.providerFactory.Register("simple", fake.FactoryFunc(&fake.SimpleProvider{}))
+
+		im := newAuthMonitor()
+		if err := op.handleIngressMonitor(t, im); err == nil {
+			t.Errorf("Expected an error for the missing Secret")
+		}
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionCredentialsResolved, v1.ConditionFalse, reasonSecretNotFound)
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionFalse, reasonSecretNotFound)
+	})
+}
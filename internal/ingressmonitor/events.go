@@ -0,0 +1,81 @@
+package ingressmonitor
+
+import (
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	eventReasonCreated               = "Created"
+	eventReasonAdopted               = "Adopted"
+	eventReasonUpdated               = "Updated"
+	eventReasonRecreated             = "Recreated"
+	eventReasonDeleted               = "Deleted"
+	eventReasonGarbageCollected      = "GarbageCollected"
+	eventReasonProviderError         = "ProviderError"
+	eventReasonInvalidAnnotation     = "InvalidAnnotation"
+	eventReasonCertificateExpiring   = "CertificateExpiring"
+	eventReasonUnsupportedAssertions = "UnsupportedAssertions"
+	eventReasonPaused                = "Paused"
+	eventReasonResumed               = "Resumed"
+	eventReasonPauseUnsupported      = "PauseUnsupported"
+)
+
+// recordEvent records an Event for the given IngressMonitor. The same Event is
+// recorded on the Monitor and the Ingress, Service or HTTPRoute it's linked
+// to, so that users can find out what happened to their monitors by
+// describing the resources they manage themselves.
+func (o *Operator) recordEvent(im *v1alpha1.IngressMonitor, eventType, reason, msgFmt string, args ...interface{}) {
+	for _, obj := range o.eventObjects(im) {
+		o.recorder.Eventf(obj, eventType, reason, msgFmt, args...)
+	}
+}
+
+// eventObjects fetches the objects which should receive the Events for the
+// given IngressMonitor. Objects which can't be found in the cache anymore are
+// skipped.
+func (o *Operator) eventObjects(im *v1alpha1.IngressMonitor) []runtime.Object {
+	objs := []runtime.Object{im}
+
+	ll := logrus.WithFields(logrus.Fields{
+		"ingress_monitor_namespace": im.Namespace,
+		"ingress_monitor_name":      im.Name,
+	})
+
+	if name, ok := im.Labels[monitorLabel]; ok {
+		if mon, err := o.mLister.Monitors(im.Namespace).Get(name); err == nil {
+			objs = append(objs, mon)
+		} else {
+			ll.WithError(err).Debug("Could not get Monitor to record Event")
+		}
+	}
+
+	if name, ok := im.Labels[ingressLabel]; ok {
+		if ing, err := o.ingLister.Ingresses(im.Namespace).Get(name); err == nil {
+			objs = append(objs, ing)
+		} else {
+			ll.WithError(err).Debug("Could not get Ingress to record Event")
+		}
+	}
+
+	if name, ok := im.Labels[serviceLabel]; ok {
+		if svc, err := o.svcLister.Services(im.Namespace).Get(name); err == nil {
+			objs = append(objs, svc)
+		} else {
+			ll.WithError(err).Debug("Could not get Service to record Event")
+		}
+	}
+
+	if name, ok := im.Labels[httpRouteLabel]; ok && o.routeLister != nil {
+		if route, err := o.routeLister.ByNamespace(im.Namespace).Get(name); err == nil {
+			objs = append(objs, route)
+		} else {
+			ll.WithError(err).Debug("Could not get HTTPRoute to record Event")
+		}
+	}
+
+	return objs
+}
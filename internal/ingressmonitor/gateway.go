@@ -0,0 +1,337 @@
+package ingressmonitor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/sirupsen/logrus"
+)
+
+// gatewayGroup is the API group of the Gateway API. The Gateway API types are
+// read from unstructured objects, so the Operator doesn't depend on the CRDs
+// being installed.
+const gatewayGroup = "gateway.networking.k8s.io"
+
+// gatewayVersions are the Gateway API versions the Operator can work with, in
+// order of preference.
+var gatewayVersions = []string{"v1", "v1beta1"}
+
+// gatewayGroupVersion uses discovery to find out which version of the Gateway
+// API should be used to watch HTTPRoutes and Gateways. An empty GroupVersion
+// is returned when the Gateway API isn't installed.
+func gatewayGroupVersion(kc kubernetes.Interface) (schema.GroupVersion, error) {
+	groups, err := kc.Discovery().ServerGroups()
+	if err != nil {
+		return schema.GroupVersion{}, err
+	}
+
+	served := map[string]bool{}
+	for _, group := range groups.Groups {
+		if group.Name != gatewayGroup {
+			continue
+		}
+
+		for _, version := range group.Versions {
+			served[version.Version] = true
+		}
+	}
+
+	for _, version := range gatewayVersions {
+		if !served[version] {
+			continue
+		}
+
+		gv := schema.GroupVersion{Group: gatewayGroup, Version: version}
+		resources, err := kc.Discovery().ServerResourcesForGroupVersion(gv.String())
+		if err != nil {
+			return schema.GroupVersion{}, err
+		}
+
+		var routes, gateways bool
+		for _, res := range resources.APIResources {
+			routes = routes || res.Name == "httproutes"
+			gateways = gateways || res.Name == "gateways"
+		}
+
+		if routes && gateways {
+			return gv, nil
+		}
+	}
+
+	return schema.GroupVersion{}, nil
+}
+
+// httpRoute is the part of a Gateway API HTTPRoute the Operator uses.
+type httpRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec struct {
+		ParentRefs []parentReference `json:"parentRefs"`
+		Hostnames  []string          `json:"hostnames"`
+		Rules      []struct {
+			BackendRefs []backendReference `json:"backendRefs"`
+		} `json:"rules"`
+	} `json:"spec"`
+}
+
+type parentReference struct {
+	Group       *string `json:"group"`
+	Kind        *string `json:"kind"`
+	Namespace   *string `json:"namespace"`
+	Name        string  `json:"name"`
+	SectionName *string `json:"sectionName"`
+}
+
+type backendReference struct {
+	Group     *string `json:"group"`
+	Kind      *string `json:"kind"`
+	Namespace *string `json:"namespace"`
+	Name      string  `json:"name"`
+}
+
+// services returns the names of the Services in the namespace of the
+// HTTPRoute which it routes traffic to.
+func (r *httpRoute) services() []string {
+	var names []string
+	for _, rule := range r.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			if (ref.Group != nil && *ref.Group != "") ||
+				(ref.Kind != nil && *ref.Kind != "Service") ||
+				(ref.Namespace != nil && *ref.Namespace != r.Namespace) {
+				continue
+			}
+
+			names = append(names, ref.Name)
+		}
+	}
+
+	return names
+}
+
+// gateway is the part of a Gateway API Gateway the Operator uses.
+type gateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec struct {
+		Listeners []listener `json:"listeners"`
+	} `json:"spec"`
+}
+
+type listener struct {
+	Name     string  `json:"name"`
+	Hostname *string `json:"hostname"`
+	Port     int32   `json:"port"`
+	Protocol string  `json:"protocol"`
+}
+
+func fromUnstructured(obj interface{}, into interface{}) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("Expected an unstructured object, got %T", obj)
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), into)
+}
+
+// selectedRoutes lists the HTTPRoutes which are selected by the Monitor. When
+// the Gateway API isn't installed, no HTTPRoutes are selected.
+func (o *Operator) selectedRoutes(obj *v1alpha1.Monitor) ([]*unstructured.Unstructured, error) {
+	if obj.Spec.HTTPRoutes == nil {
+		return nil, nil
+	}
+
+	if o.routeLister == nil {
+		logrus.WithFields(logrus.Fields{
+			"monitor_namespace": obj.Namespace,
+			"monitor_name":      obj.Name,
+		}).Debug("The Gateway API isn't installed, not selecting HTTPRoutes")
+		return nil, nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(obj.Spec.HTTPRoutes.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("Could not create HTTPRoute selector for %s:%s: %s", obj.Namespace, obj.Name, err)
+	}
+
+	routeList, err := o.routeLister.ByNamespace(obj.Namespace).List(sel)
+	if err != nil {
+		return nil, fmt.Errorf("Could not list HTTPRoutes: %s", err)
+	}
+
+	var selected []*unstructured.Unstructured
+	for _, route := range routeList {
+		selected = append(selected, route.(*unstructured.Unstructured))
+	}
+
+	return selected, nil
+}
+
+// monitorSelectsRoute reports if the Monitor selects the given HTTPRoute.
+func monitorSelectsRoute(mon *v1alpha1.Monitor, route *unstructured.Unstructured) bool {
+	if mon.Spec.HTTPRoutes == nil {
+		return false
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(mon.Spec.HTTPRoutes.Selector)
+	if err != nil {
+		return false
+	}
+
+	return sel.Matches(labels.Set(route.GetLabels()))
+}
+
+// routeTargets returns a target for every hostname of the given HTTPRoute.
+// Wildcard hostnames can't be monitored and are skipped.
+func (o *Operator) routeTargets(obj *unstructured.Unstructured) ([]target, error) {
+	route := new(httpRoute)
+	if err := fromUnstructured(obj, route); err != nil {
+		return nil, fmt.Errorf("Could not read HTTPRoute %s:%s: %s", obj.GetNamespace(), obj.GetName(), err)
+	}
+
+	ref := *metav1.NewControllerRef(obj, o.routeGV.WithKind("HTTPRoute"))
+
+	var targets []target
+	for _, host := range o.routeHostnames(route) {
+		if strings.HasPrefix(host, "*") {
+			continue
+		}
+
+		targets = append(targets, target{
+			owner:    obj,
+			ownerRef: ref,
+			name:     fmt.Sprintf("%s-%s", route.Name, shortHash("HTTPRoute/"+host, 16)),
+			host:     host,
+			baseURL:  o.routeBaseURL(route, host),
+			services: route.services(),
+			labels:   targetLabels(httpRouteLabel, route.Name, host),
+		})
+	}
+
+	if len(targets) == 0 {
+		logrus.WithFields(logrus.Fields{
+			"http_route_namespace": route.Namespace,
+			"http_route_name":      route.Name,
+		}).Info("HTTPRoute has no hostnames which can be monitored, not monitoring it")
+	}
+
+	return targets, nil
+}
+
+// routeHostnames returns the hostnames of the HTTPRoute. An HTTPRoute without
+// hostnames serves the hostnames of the listeners it's attached to.
+func (o *Operator) routeHostnames(route *httpRoute) []string {
+	if len(route.Spec.Hostnames) > 0 {
+		return route.Spec.Hostnames
+	}
+
+	seen := map[string]bool{}
+	var hosts []string
+	for _, gw := range o.routeGateways(route) {
+		for _, l := range gw.listeners {
+			if l.Hostname == nil || seen[*l.Hostname] || (gw.sectionName != nil && *gw.sectionName != l.Name) {
+				continue
+			}
+
+			seen[*l.Hostname] = true
+			hosts = append(hosts, *l.Hostname)
+		}
+	}
+
+	return hosts
+}
+
+// routeBaseURL works out how the given hostname of an HTTPRoute is served by
+// looking at the listeners of its parent Gateways. Listeners using HTTPS are
+// preferred. When no listener can be found, plain HTTP is assumed.
+func (o *Operator) routeBaseURL(route *httpRoute, host string) string {
+	var httpPort, httpsPort int32
+	for _, gw := range o.routeGateways(route) {
+		for _, l := range gw.listeners {
+			if !listenerMatches(l, gw.sectionName, host) {
+				continue
+			}
+
+			switch {
+			case l.Protocol == "HTTPS" && httpsPort == 0:
+				httpsPort = l.Port
+			case l.Protocol == "HTTP" && httpPort == 0:
+				httpPort = l.Port
+			}
+		}
+	}
+
+	scheme, port := "http", httpPort
+	if httpsPort != 0 {
+		scheme, port = "https", httpsPort
+	}
+
+	if port == 0 || isDefaultPort(scheme, port) {
+		return fmt.Sprintf("%s://%s", scheme, host)
+	}
+
+	return fmt.Sprintf("%s://%s:%d", scheme, host, port)
+}
+
+type parentGateway struct {
+	listeners   []listener
+	sectionName *string
+}
+
+// routeGateways fetches the Gateways the HTTPRoute is attached to. Gateways
+// which can't be found are skipped.
+func (o *Operator) routeGateways(route *httpRoute) []parentGateway {
+	var gws []parentGateway
+	for _, ref := range route.Spec.ParentRefs {
+		if (ref.Group != nil && *ref.Group != gatewayGroup) || (ref.Kind != nil && *ref.Kind != "Gateway") {
+			continue
+		}
+
+		ns := route.Namespace
+		if ref.Namespace != nil {
+			ns = *ref.Namespace
+		}
+
+		obj, err := o.gwLister.ByNamespace(ns).Get(ref.Name)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"gateway_namespace": ns,
+				"gateway_name":      ref.Name,
+			}).WithError(err).Debug("Could not get Gateway for HTTPRoute")
+			continue
+		}
+
+		gw := new(gateway)
+		if err := fromUnstructured(obj, gw); err != nil {
+			logrus.WithError(err).Error("Could not read Gateway")
+			continue
+		}
+
+		gws = append(gws, parentGateway{listeners: gw.Spec.Listeners, sectionName: ref.SectionName})
+	}
+
+	return gws
+}
+
+// listenerMatches reports if the given host is served by the listener.
+func listenerMatches(l listener, sectionName *string, host string) bool {
+	if sectionName != nil && *sectionName != l.Name {
+		return false
+	}
+
+	if l.Hostname == nil || *l.Hostname == host {
+		return true
+	}
+
+	return strings.HasPrefix(*l.Hostname, "*.") && strings.HasSuffix(host, (*l.Hostname)[1:])
+}
@@ -0,0 +1,156 @@
+package ingressmonitor
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGatewayGroupVersion(t *testing.T) {
+	tcs := []struct {
+		name      string
+		resources []*metav1.APIResourceList
+		exp       string
+	}{
+		{"without the Gateway API", nil, ""},
+		{"with v1", []*metav1.APIResourceList{
+			gatewayResources("v1beta1", "httproutes", "gateways"),
+			gatewayResources("v1", "httproutes", "gateways"),
+		}, "gateway.networking.k8s.io/v1"},
+		{"with v1beta1", []*metav1.APIResourceList{
+			gatewayResources("v1beta1", "httproutes", "gateways"),
+		}, "gateway.networking.k8s.io/v1beta1"},
+		{"without HTTPRoutes", []*metav1.APIResourceList{
+			gatewayResources("v1", "gateways"),
+		}, ""},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			kc := k8sfake.NewSimpleClientset()
+			kc.Resources = tc.resources
+
+			gv, err := gatewayGroupVersion(kc)
+			errEquals(t, nil, err)
+
+			act := gv.String()
+			if gv.Empty() {
+				act = ""
+			}
+			strEquals(t, tc.exp, act)
+		})
+	}
+}
+
+func TestOperator_RouteBaseURL(t *testing.T) {
+	listener := func(name, protocol string, port int64, hostname string) interface{} {
+		l := map[string]interface{}{"name": name, "protocol": protocol, "port": port}
+		if hostname != "" {
+			l["hostname"] = hostname
+		}
+		return l
+	}
+
+	tcs := []struct {
+		name      string
+		host      string
+		section   string
+		listeners []interface{}
+		exp       string
+	}{
+		{"with an HTTPS listener", "route.example.com", "", []interface{}{
+			listener("http", "HTTP", 80, ""),
+			listener("https", "HTTPS", 443, "*.example.com"),
+		}, "https://route.example.com"},
+		{"with an HTTPS listener for another host", "route.example.org", "", []interface{}{
+			listener("http", "HTTP", 80, ""),
+			listener("https", "HTTPS", 443, "*.example.com"),
+		}, "http://route.example.org"},
+		{"with a section name", "route.example.com", "http", []interface{}{
+			listener("http", "HTTP", 8080, ""),
+			listener("https", "HTTPS", 443, ""),
+		}, "http://route.example.com:8080"},
+		{"with a non default port", "route.example.com", "", []interface{}{
+			listener("https", "HTTPS", 8443, "route.example.com"),
+		}, "https://route.example.com:8443"},
+		{"without a Gateway", "route.example.com", "", nil, "http://route.example.com"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []optionFunc
+			if tc.listeners != nil {
+				gw := newGateway()
+				unstructured.SetNestedSlice(gw.Object, tc.listeners, "spec", "listeners")
+				opts = append(opts, withGateways(gw))
+			} else {
+				opts = append(opts, withGateways())
+			}
+			op := newOperator(t, opts...)
+
+			obj := newHTTPRoute()
+			if tc.section != "" {
+				unstructured.SetNestedSlice(obj.Object, []interface{}{
+					map[string]interface{}{"name": "go-gateway", "sectionName": tc.section},
+				}, "spec", "parentRefs")
+			}
+
+			route := new(httpRoute)
+			errEquals(t, nil, fromUnstructured(obj, route))
+			strEquals(t, tc.exp, op.op.routeBaseURL(route, tc.host))
+		})
+	}
+}
+
+func TestOperator_RouteHostnames(t *testing.T) {
+	tcs := []struct {
+		name      string
+		hostnames []interface{}
+		section   string
+		exp       []string
+	}{
+		{"with hostnames", []interface{}{"route.example.com"}, "", []string{"route.example.com"}},
+		{"without hostnames", nil, "", []string{"*.example.com", "route.example.org"}},
+		{"without hostnames for the section", nil, "http", nil},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			gw := newGateway()
+			unstructured.SetNestedSlice(gw.Object, []interface{}{
+				map[string]interface{}{"name": "http", "port": int64(80), "protocol": "HTTP"},
+				map[string]interface{}{"name": "https", "port": int64(443), "protocol": "HTTPS", "hostname": "*.example.com"},
+				map[string]interface{}{"name": "https-alt", "port": int64(8443), "protocol": "HTTPS", "hostname": "route.example.org"},
+			}, "spec", "listeners")
+			op := newOperator(t, withGateways(gw))
+
+			obj := newHTTPRoute()
+			unstructured.RemoveNestedField(obj.Object, "spec", "hostnames")
+			if tc.hostnames != nil {
+				unstructured.SetNestedSlice(obj.Object, tc.hostnames, "spec", "hostnames")
+			}
+			if tc.section != "" {
+				unstructured.SetNestedSlice(obj.Object, []interface{}{
+					map[string]interface{}{"name": "go-gateway", "sectionName": tc.section},
+				}, "spec", "parentRefs")
+			}
+
+			route := new(httpRoute)
+			errEquals(t, nil, fromUnstructured(obj, route))
+			if act := op.op.routeHostnames(route); !reflect.DeepEqual(tc.exp, act) {
+				t.Errorf("Expected hostnames %v, got %v", tc.exp, act)
+			}
+		})
+	}
+}
+
+func gatewayResources(version string, resources ...string) *metav1.APIResourceList {
+	list := &metav1.APIResourceList{GroupVersion: gatewayGroup + "/" + version}
+	for _, res := range resources {
+		list.APIResources = append(list.APIResources, metav1.APIResource{Name: res})
+	}
+	return list
+}
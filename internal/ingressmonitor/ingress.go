@@ -0,0 +1,287 @@
+package ingressmonitor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ingressClassAnnotation is the annotation which was used to configure the
+// class of an Ingress before the `ingressClassName` field was introduced.
+const ingressClassAnnotation = "kubernetes.io/ingress.class"
+
+// ingressGroupVersion uses discovery to find out which API version should be
+// used to watch Ingresses. The networking.k8s.io/v1 API is preferred, older
+// clusters only serve Ingresses through extensions/v1beta1.
+func ingressGroupVersion(kc kubernetes.Interface) (schema.GroupVersion, error) {
+	resources, err := kc.Discovery().ServerResourcesForGroupVersion(networkingv1.SchemeGroupVersion.String())
+	if err != nil && !kerrors.IsNotFound(err) {
+		return schema.GroupVersion{}, err
+	}
+
+	if resources != nil {
+		for _, res := range resources.APIResources {
+			if res.Name == "ingresses" {
+				return networkingv1.SchemeGroupVersion, nil
+			}
+		}
+	}
+
+	return extv1beta1.SchemeGroupVersion, nil
+}
+
+// newLegacyIngressInformer sets up an informer which watches Ingresses through
+// the extensions/v1beta1 API and converts them to networking.k8s.io/v1
+// Ingresses, so the rest of the Operator only has to deal with a single
+// version.
+func newLegacyIngressInformer(kc kubernetes.Interface, resync time.Duration) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list, err := kc.ExtensionsV1beta1().Ingresses(metav1.NamespaceAll).List(context.TODO(), options)
+				if err != nil {
+					return nil, err
+				}
+
+				converted := &networkingv1.IngressList{ListMeta: list.ListMeta}
+				for i := range list.Items {
+					converted.Items = append(converted.Items, *convertIngress(&list.Items[i]))
+				}
+				return converted, nil
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				w, err := kc.ExtensionsV1beta1().Ingresses(metav1.NamespaceAll).Watch(context.TODO(), options)
+				if err != nil {
+					return nil, err
+				}
+
+				return watch.Filter(w, func(in watch.Event) (watch.Event, bool) {
+					if ing, ok := in.Object.(*extv1beta1.Ingress); ok {
+						in.Object = convertIngress(ing)
+					}
+					return in, true
+				}), nil
+			},
+		},
+		&networkingv1.Ingress{},
+		resync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+}
+
+// convertIngress converts an extensions/v1beta1 Ingress to its
+// networking.k8s.io/v1 representation. Only the metadata, spec and load
+// balancer status are converted.
+func convertIngress(in *extv1beta1.Ingress) *networkingv1.Ingress {
+	out := &networkingv1.Ingress{
+		ObjectMeta: *in.ObjectMeta.DeepCopy(),
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: in.Spec.IngressClassName,
+		},
+		Status: networkingv1.IngressStatus{
+			LoadBalancer: *in.Status.LoadBalancer.DeepCopy(),
+		},
+	}
+
+	if in.Spec.Backend != nil {
+		out.Spec.DefaultBackend = convertIngressBackend(*in.Spec.Backend)
+	}
+
+	for _, tls := range in.Spec.TLS {
+		out.Spec.TLS = append(out.Spec.TLS, networkingv1.IngressTLS{
+			Hosts:      append([]string(nil), tls.Hosts...),
+			SecretName: tls.SecretName,
+		})
+	}
+
+	for _, rule := range in.Spec.Rules {
+		outRule := networkingv1.IngressRule{Host: rule.Host}
+		if rule.HTTP != nil {
+			outRule.HTTP = &networkingv1.HTTPIngressRuleValue{}
+			for _, path := range rule.HTTP.Paths {
+				pathType := networkingv1.PathTypeImplementationSpecific
+				if path.PathType != nil {
+					pathType = networkingv1.PathType(*path.PathType)
+				}
+
+				outRule.HTTP.Paths = append(outRule.HTTP.Paths, networkingv1.HTTPIngressPath{
+					Path:     path.Path,
+					PathType: &pathType,
+					Backend:  *convertIngressBackend(path.Backend),
+				})
+			}
+		}
+		out.Spec.Rules = append(out.Spec.Rules, outRule)
+	}
+
+	return out
+}
+
+func convertIngressBackend(in extv1beta1.IngressBackend) *networkingv1.IngressBackend {
+	if in.Resource != nil {
+		return &networkingv1.IngressBackend{Resource: in.Resource.DeepCopy()}
+	}
+
+	svc := &networkingv1.IngressServiceBackend{Name: in.ServiceName}
+	if in.ServicePort.StrVal != "" {
+		svc.Port.Name = in.ServicePort.StrVal
+	} else {
+		svc.Port.Number = in.ServicePort.IntVal
+	}
+
+	return &networkingv1.IngressBackend{Service: svc}
+}
+
+// ingressTargets returns a target for every host of the given Ingress. Hosts
+// which are listed in the TLS section of the Ingress are monitored over HTTPS.
+// When perPath is set, every path of a rule gets its own target instead.
+func ingressTargets(ing *networkingv1.Ingress, gv schema.GroupVersion, perPath bool) []target {
+	// we can only assign one reference that controls the object, ensure that
+	// it's the Ingress so that we can still perform garbage collection.
+	ref := *metav1.NewControllerRef(ing, gv.WithKind("Ingress"))
+
+	// Every host of the Ingress should resolve to its load balancer.
+	var records []string
+	for _, lb := range ing.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			records = append(records, lb.IP)
+		} else if lb.Hostname != "" {
+			records = append(records, lb.Hostname)
+		}
+	}
+
+	var targets []target
+	for _, rule := range ing.Spec.Rules {
+		scheme, secret := "http", ""
+	TLSLoop:
+		for _, tlsList := range ing.Spec.TLS {
+			for _, host := range tlsList.Hosts {
+				if host == rule.Host {
+					scheme, secret = "https", tlsList.SecretName
+					break TLSLoop
+				}
+			}
+		}
+
+		t := target{
+			owner:     ing,
+			ownerRef:  ref,
+			name:      fmt.Sprintf("%s-%s", ing.Name, shortHash(rule.Host, 16)),
+			host:      rule.Host,
+			baseURL:   fmt.Sprintf("%s://%s", scheme, rule.Host),
+			tlsSecret: secret,
+			records:   records,
+			services:  ruleServices(ing, rule),
+			labels:    targetLabels(ingressLabel, ing.Name, rule.Host),
+		}
+
+		if !perPath || rule.HTTP == nil || len(rule.HTTP.Paths) == 0 {
+			targets = append(targets, t)
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			pt := t
+			pt.path = path.Path
+			pt.services = backendServices(path.Backend)
+			// Both the host and path are part of the hash, so every path
+			// gets its own IngressMonitor.
+			pt.name = fmt.Sprintf("%s-%s", ing.Name, shortHash(rule.Host+path.Path, 16))
+			targets = append(targets, pt)
+		}
+	}
+
+	return targets
+}
+
+// ruleServices returns the names of the Services which serve the given rule
+// of the Ingress. Rules without paths are served by the default backend.
+func ruleServices(ing *networkingv1.Ingress, rule networkingv1.IngressRule) []string {
+	if rule.HTTP == nil || len(rule.HTTP.Paths) == 0 {
+		if ing.Spec.DefaultBackend == nil {
+			return nil
+		}
+		return backendServices(*ing.Spec.DefaultBackend)
+	}
+
+	var names []string
+	for _, path := range rule.HTTP.Paths {
+		names = append(names, backendServices(path.Backend)...)
+	}
+
+	return names
+}
+
+func backendServices(backend networkingv1.IngressBackend) []string {
+	if backend.Service == nil {
+		return nil
+	}
+
+	return []string{backend.Service.Name}
+}
+
+// ingressClass returns the class of the given Ingress. The `ingressClassName`
+// field takes precedence over the legacy annotation.
+func ingressClass(ing *networkingv1.Ingress) string {
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName
+	}
+
+	return ing.Annotations[ingressClassAnnotation]
+}
+
+// monitorSelectsIngress reports if the Monitor selects the given Ingress,
+// either by its labels or its class.
+func monitorSelectsIngress(mon *v1alpha1.Monitor, ing *networkingv1.Ingress) bool {
+	sel, err := metav1.LabelSelectorAsSelector(mon.Spec.Selector)
+	if err != nil {
+		return false
+	}
+
+	return sel.Matches(labels.Set(ing.Labels)) && monitorSelectsClass(mon, ing)
+}
+
+// monitorSelectsClass reports if the Ingress has the class the Monitor is
+// limited to. When the Monitor isn't limited to a class, all Ingresses match.
+func monitorSelectsClass(mon *v1alpha1.Monitor, ing *networkingv1.Ingress) bool {
+	if mon.Spec.IngressClassName == nil {
+		return true
+	}
+
+	return *mon.Spec.IngressClassName == ingressClass(ing)
+}
+
+// migrateOwnerReferences replaces the owner reference to the Ingress with the
+// given reference. IngressMonitors created before the Operator watched
+// networking.k8s.io/v1 Ingresses reference the extensions/v1beta1 API, which
+// isn't served by current clusters.
+func migrateOwnerReferences(refs []metav1.OwnerReference, ingRef metav1.OwnerReference) []metav1.OwnerReference {
+	var migrated []metav1.OwnerReference
+	var found bool
+	for _, ref := range refs {
+		if ref.UID == ingRef.UID {
+			ref = ingRef
+			found = true
+		}
+		migrated = append(migrated, ref)
+	}
+
+	if !found {
+		migrated = append(migrated, ingRef)
+	}
+
+	return migrated
+}
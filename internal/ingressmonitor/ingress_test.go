@@ -0,0 +1,257 @@
+package ingressmonitor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestIngressGroupVersion(t *testing.T) {
+	t.Run("with networking.k8s.io/v1 Ingresses", func(t *testing.T) {
+		kc := k8sfake.NewSimpleClientset()
+		kc.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: networkingv1.SchemeGroupVersion.String(),
+				APIResources: []metav1.APIResource{{Name: "ingresses"}},
+			},
+		}
+
+		gv, err := ingressGroupVersion(kc)
+		errEquals(t, nil, err)
+		strEquals(t, "networking.k8s.io/v1", gv.String())
+	})
+
+	t.Run("without networking.k8s.io/v1 Ingresses", func(t *testing.T) {
+		kc := k8sfake.NewSimpleClientset()
+		kc.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: networkingv1.SchemeGroupVersion.String(),
+				APIResources: []metav1.APIResource{{Name: "networkpolicies"}},
+			},
+		}
+
+		gv, err := ingressGroupVersion(kc)
+		errEquals(t, nil, err)
+		strEquals(t, "extensions/v1beta1", gv.String())
+	})
+}
+
+func TestLegacyIngressInformer(t *testing.T) {
+	kc := k8sfake.NewSimpleClientset(newLegacyIngress())
+	inf := newLegacyIngressInformer(kc, 0)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	go inf.Run(stopCh)
+	if !cache.WaitForCacheSync(stopCh, inf.HasSynced) {
+		t.Fatalf("Could not sync the legacy Ingress informer")
+	}
+
+	obj, ok, err := inf.GetIndexer().GetByKey("testing/go-ingress")
+	errEquals(t, nil, err)
+	if !ok {
+		t.Fatalf("Expected the Ingress to be in the cache")
+	}
+
+	if _, ok := obj.(*networkingv1.Ingress); !ok {
+		t.Errorf("Expected a networking.k8s.io/v1 Ingress, got %T", obj)
+	}
+}
+
+func TestIngressTargets(t *testing.T) {
+	ing := newIngress()
+	ing.Spec.Rules = []networkingv1.IngressRule{
+		{
+			Host: "api.example.com",
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/api"},
+						{Path: "/web/"},
+					},
+				},
+			},
+		},
+		{Host: "www.example.com"},
+	}
+
+	t.Run("per host", func(t *testing.T) {
+		targets := ingressTargets(ing, networkingv1.SchemeGroupVersion, false)
+		if len(targets) != 2 {
+			t.Fatalf("Expected 2 targets, got %d", len(targets))
+		}
+
+		strEquals(t, "https://api.example.com/_healthz", targets[0].url("/_healthz"))
+		strEquals(t, "http://www.example.com/_healthz", targets[1].url("/_healthz"))
+	})
+
+	t.Run("per path", func(t *testing.T) {
+		targets := ingressTargets(ing, networkingv1.SchemeGroupVersion, true)
+		if len(targets) != 3 {
+			t.Fatalf("Expected 3 targets, got %d", len(targets))
+		}
+
+		strEquals(t, "https://api.example.com/api/_healthz", targets[0].url("/_healthz"))
+		strEquals(t, "https://api.example.com/web/_healthz", targets[1].url("/_healthz"))
+		strEquals(t, "http://www.example.com/_healthz", targets[2].url("/_healthz"))
+
+		if targets[0].name == targets[1].name {
+			t.Errorf("Expected every path to have its own name, got %s", targets[0].name)
+		}
+
+		// Rules without paths keep the name they had before paths were
+		// monitored.
+		strEquals(t, ingressTargets(ing, networkingv1.SchemeGroupVersion, false)[1].name, targets[2].name)
+	})
+}
+
+func TestConvertIngress(t *testing.T) {
+	ing := convertIngress(newLegacyIngress())
+
+	strEquals(t, "go-ingress", ing.Name)
+	strEquals(t, "nginx", ingressClass(ing), "class")
+
+	if ing.Spec.DefaultBackend == nil || ing.Spec.DefaultBackend.Service == nil {
+		t.Fatalf("Expected the default backend to be converted")
+	}
+	strEquals(t, "http", ing.Spec.DefaultBackend.Service.Port.Name, "default backend port")
+
+	if len(ing.Spec.Rules) != 1 || ing.Spec.Rules[0].HTTP == nil || len(ing.Spec.Rules[0].HTTP.Paths) != 1 {
+		t.Fatalf("Expected a single rule with a single path, got %#v", ing.Spec.Rules)
+	}
+
+	path := ing.Spec.Rules[0].HTTP.Paths[0]
+	strEquals(t, "api.example.com", ing.Spec.Rules[0].Host)
+	strEquals(t, "/", path.Path)
+	strEquals(t, string(networkingv1.PathTypeImplementationSpecific), string(*path.PathType), "path type")
+	strEquals(t, "go-service", path.Backend.Service.Name, "backend")
+
+	if path.Backend.Service.Port.Number != 8080 {
+		t.Errorf("Expected backend port to be 8080, got %d", path.Backend.Service.Port.Number)
+	}
+
+	if !reflect.DeepEqual([]string{"api.example.com"}, ing.Spec.TLS[0].Hosts) {
+		t.Errorf("Expected the TLS hosts to be converted, got %v", ing.Spec.TLS)
+	}
+}
+
+func TestMonitorSelectsIngress(t *testing.T) {
+	tcs := []struct {
+		name      string
+		className *string
+		ing       func(*networkingv1.Ingress)
+		selected  bool
+	}{
+		{"without a class", nil, func(*networkingv1.Ingress) {}, true},
+		{"with a matching class", ptrString("nginx"), func(ing *networkingv1.Ingress) {
+			ing.Spec.IngressClassName = ptrString("nginx")
+		}, true},
+		{"with a matching annotation", ptrString("nginx"), func(ing *networkingv1.Ingress) {
+			ing.Annotations = map[string]string{ingressClassAnnotation: "nginx"}
+		}, true},
+		{"with the field taking precedence", ptrString("nginx"), func(ing *networkingv1.Ingress) {
+			ing.Annotations = map[string]string{ingressClassAnnotation: "nginx"}
+			ing.Spec.IngressClassName = ptrString("traefik")
+		}, false},
+		{"with a different class", ptrString("nginx"), func(ing *networkingv1.Ingress) {
+			ing.Spec.IngressClassName = ptrString("traefik")
+		}, false},
+		{"with different labels", nil, func(ing *networkingv1.Ingress) {
+			ing.Labels = map[string]string{"team": "other"}
+		}, false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			mon := newMonitor()
+			mon.Spec.IngressClassName = tc.className
+
+			ing := newIngress()
+			tc.ing(ing)
+
+			if monitorSelectsIngress(mon, ing) != tc.selected {
+				t.Errorf("Expected selected to be %t", tc.selected)
+			}
+		})
+	}
+
+	t.Run("with an invalid selector", func(t *testing.T) {
+		mon := newMonitor()
+		mon.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{
+			{Key: "team", Operator: "Unknown"},
+		}
+
+		if monitorSelectsIngress(mon, newIngress()) {
+			t.Errorf("Expected the Ingress not to be selected")
+		}
+	})
+}
+
+func TestMigrateOwnerReferences(t *testing.T) {
+	ingRef := *metav1.NewControllerRef(newIngress(), networkingv1.SchemeGroupVersion.WithKind("Ingress"))
+	monRef := *metav1.NewControllerRef(newMonitor(), v1alpha1.SchemeGroupVersion.WithKind("Monitor"))
+	monRef.Controller = nil
+
+	t.Run("with a legacy reference", func(t *testing.T) {
+		legacyRef := *metav1.NewControllerRef(newIngress(), extv1beta1.SchemeGroupVersion.WithKind("Ingress"))
+
+		refs := migrateOwnerReferences([]metav1.OwnerReference{legacyRef, monRef}, ingRef)
+		if !reflect.DeepEqual([]metav1.OwnerReference{ingRef, monRef}, refs) {
+			t.Errorf("Expected the Ingress reference to be replaced, got %v", refs)
+		}
+	})
+
+	t.Run("without a reference", func(t *testing.T) {
+		refs := migrateOwnerReferences([]metav1.OwnerReference{monRef}, ingRef)
+		if !reflect.DeepEqual([]metav1.OwnerReference{monRef, ingRef}, refs) {
+			t.Errorf("Expected the Ingress reference to be added, got %v", refs)
+		}
+	})
+}
+
+func newLegacyIngress() *extv1beta1.Ingress {
+	return &extv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "go-ingress",
+			Namespace:   "testing",
+			UID:         "go-ingress-uid",
+			Annotations: map[string]string{ingressClassAnnotation: "nginx"},
+		},
+		Spec: extv1beta1.IngressSpec{
+			Backend: &extv1beta1.IngressBackend{
+				ServiceName: "go-service",
+				ServicePort: intstr.FromString("http"),
+			},
+			TLS: []extv1beta1.IngressTLS{
+				{Hosts: []string{"api.example.com"}, SecretName: "go-tls"},
+			},
+			Rules: []extv1beta1.IngressRule{
+				{
+					Host: "api.example.com",
+					IngressRuleValue: extv1beta1.IngressRuleValue{
+						HTTP: &extv1beta1.HTTPIngressRuleValue{
+							Paths: []extv1beta1.HTTPIngressPath{
+								{
+									Path: "/",
+									Backend: extv1beta1.IngressBackend{
+										ServiceName: "go-service",
+										ServicePort: intstr.FromInt(8080),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,136 @@
+package ingressmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LeaderElectionConfig describes the Lease the replicas of the Operator use to
+// elect the replica which syncs the monitors with the providers.
+type LeaderElectionConfig struct {
+	// Namespace and Name identify the Lease.
+	Namespace string
+	Name      string
+
+	// Identity uniquely identifies this replica, like the name of its pod.
+	Identity string
+
+	// LeaseDuration is how long followers wait before they try to take over
+	// a Lease which hasn't been renewed.
+	LeaseDuration time.Duration
+	// RenewDeadline is how long the leader keeps trying to renew the Lease
+	// before it gives up its leadership.
+	RenewDeadline time.Duration
+	// RetryPeriod is how long replicas wait between attempts to acquire or
+	// renew the Lease.
+	RetryPeriod time.Duration
+}
+
+// ErrLeaseLost is returned by RunWithLeaderElection when this replica loses
+// the Lease while it's the leader.
+var ErrLeaseLost = errors.New("lost the Lease")
+
+// RunWithLeaderElection starts the Operator like Run, but only runs the workers
+// while this replica holds the Lease. Followers keep their informers running,
+// so they can take over with warm caches. A leader which loses the Lease stops
+// its workers and informers and returns ErrLeaseLost, syncs which are still in
+// flight might overlap with the new leader so the process should exit.
+// Otherwise, it blocks until a message is received on stopCh.
+func (o *Operator) RunWithLeaderElection(stopCh <-chan struct{}, cfg LeaderElectionConfig) error {
+	defer o.monitorQueue.ShutDown()
+	defer o.ingressMonitorQueue.ShutDown()
+
+	log := logrus.WithFields(logrus.Fields{
+		"lease_namespace": cfg.Namespace,
+		"lease_name":      cfg.Name,
+		"identity":        cfg.Identity,
+	})
+
+	// The context is cancelled when the Operator stops or loses the Lease,
+	// which stops the informers and the workers.
+	ctx, cancel := stopContext(stopCh)
+	defer cancel()
+
+	var lost int32
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta:  metav1.ObjectMeta{Namespace: cfg.Namespace, Name: cfg.Name},
+			Client:     o.kubeClient.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{Identity: cfg.Identity},
+		},
+		LeaseDuration: cfg.LeaseDuration,
+		RenewDeadline: cfg.RenewDeadline,
+		RetryPeriod:   cfg.RetryPeriod,
+		// The Lease is released when the Operator stops, so a follower can
+		// take over straight away.
+		ReleaseOnCancel: true,
+		Name:            cfg.Name,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				log.Info("Acquired the Lease, running as the leader")
+				o.setLeader(true)
+				o.runWorkers(ctx)
+			},
+			OnStoppedLeading: func() {
+				select {
+				case <-stopCh:
+				default:
+					if o.IsLeader() {
+						log.Error("Lost the Lease, stopping")
+						atomic.StoreInt32(&lost, 1)
+						cancel()
+					}
+				}
+				o.setLeader(false)
+			},
+			OnNewLeader: func(identity string) {
+				log.WithFields(logrus.Fields{"leader": identity}).Info("Observed a new leader")
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Could not set up leader election: %s", err)
+	}
+
+	if err := o.start(ctx.Done()); err != nil {
+		return err
+	}
+
+	o.setLeader(false)
+	for ctx.Err() == nil {
+		log.Info("Trying to acquire the Lease")
+		elector.Run(ctx)
+	}
+	logrus.Infof("Stopping IngressMonitor Operator")
+
+	if atomic.LoadInt32(&lost) == 1 {
+		return ErrLeaseLost
+	}
+
+	return nil
+}
+
+// IsLeader reports if this replica is running the workers which sync the
+// monitors with the providers.
+func (o *Operator) IsLeader() bool {
+	return atomic.LoadInt32(&o.leader) == 1
+}
+
+func (o *Operator) setLeader(leader bool) {
+	var v int32
+	if leader {
+		v = 1
+	}
+
+	atomic.StoreInt32(&o.leader, v)
+	o.metrics.SetLeader(leader)
+}
@@ -0,0 +1,111 @@
+package ingressmonitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOperator_RunWithLeaderElection(t *testing.T) {
+	newConfig := func() LeaderElectionConfig {
+		return LeaderElectionConfig{
+			Namespace:     "ingress-monitor",
+			Name:          "ingress-monitor",
+			Identity:      "operator-0",
+			LeaseDuration: 2 * time.Second,
+			RenewDeadline: time.Second,
+			RetryPeriod:   100 * time.Millisecond,
+		}
+	}
+
+	t.Run("with an invalid configuration", func(t *testing.T) {
+		op := newOperator(t).op
+
+		cfg := newConfig()
+		cfg.RenewDeadline = 3 * time.Second
+
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+
+		if err := op.RunWithLeaderElection(stopCh, cfg); err == nil {
+			t.Errorf("Expected an error for a renew deadline longer than the lease duration")
+		}
+	})
+
+	t.Run("acquiring the Lease", func(t *testing.T) {
+		op := newOperator(t)
+
+		stopCh := make(chan struct{})
+		errCh := make(chan error)
+		go func() {
+			errCh <- op.op.RunWithLeaderElection(stopCh, newConfig())
+		}()
+
+		deadline := time.Now().Add(5 * time.Second)
+		for !op.op.IsLeader() && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if !op.op.IsLeader() {
+			t.Fatalf("Expected the Operator to become the leader")
+		}
+
+		lease, err := op.kubeClient.CoordinationV1().Leases("ingress-monitor").Get(context.TODO(), "ingress-monitor", metav1.GetOptions{})
+		errEquals(t, nil, err, "getting the Lease")
+		if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != "operator-0" {
+			t.Errorf("Expected the Lease to be held by operator-0, got %v", lease.Spec.HolderIdentity)
+		}
+
+		close(stopCh)
+		errEquals(t, nil, <-errCh, "shutting down the operator")
+
+		if op.op.IsLeader() {
+			t.Errorf("Expected the Operator to give up its leadership")
+		}
+	})
+
+	t.Run("losing the Lease", func(t *testing.T) {
+		op := newOperator(t)
+
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		errCh := make(chan error)
+		go func() {
+			errCh <- op.op.RunWithLeaderElection(stopCh, newConfig())
+		}()
+
+		deadline := time.Now().Add(5 * time.Second)
+		for !op.op.IsLeader() && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if !op.op.IsLeader() {
+			t.Fatalf("Expected the Operator to become the leader")
+		}
+
+		// Another replica takes over the Lease.
+		lease, err := op.kubeClient.CoordinationV1().Leases("ingress-monitor").Get(context.TODO(), "ingress-monitor", metav1.GetOptions{})
+		errEquals(t, nil, err, "getting the Lease")
+
+		holder, duration, now := "operator-1", int32(60), metav1.NewMicroTime(time.Now())
+		lease.Spec.HolderIdentity = &holder
+		lease.Spec.LeaseDurationSeconds = &duration
+		lease.Spec.AcquireTime = &now
+		lease.Spec.RenewTime = &now
+		_, err = op.kubeClient.CoordinationV1().Leases("ingress-monitor").Update(context.TODO(), lease, metav1.UpdateOptions{})
+		errEquals(t, nil, err, "taking over the Lease")
+
+		select {
+		case err := <-errCh:
+			errEquals(t, ErrLeaseLost, err, "losing the Lease")
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected the Operator to stop after losing the Lease")
+		}
+
+		if op.op.IsLeader() {
+			t.Errorf("Expected the Operator to give up its leadership")
+		}
+	})
+}
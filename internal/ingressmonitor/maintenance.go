@@ -0,0 +1,294 @@
+package ingressmonitor
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// maxWindowDuration is the longest a recurring maintenance window can last.
+// It's also how far ahead the Operator looks for the next start of a
+// recurring window.
+const maxWindowDuration = 7 * 24 * time.Hour
+
+// maintenanceWindow is a validated MaintenanceWindow of a Monitor.
+type maintenanceWindow struct {
+	name     string
+	selector labels.Selector
+
+	// schedule and duration describe a recurring window.
+	schedule *schedule
+	duration time.Duration
+
+	// start and end describe a one-off window.
+	start, end time.Time
+}
+
+// parseMaintenanceWindows validates the maintenance windows of a Monitor.
+// Invalid windows are left out and reported through the returned errors.
+func parseMaintenanceWindows(windows []v1alpha1.MaintenanceWindow) ([]*maintenanceWindow, []string) {
+	var parsed []*maintenanceWindow
+	var errs []string
+	for _, w := range windows {
+		mw, err := parseMaintenanceWindow(w)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("window %q: %s", w.Name, err))
+			continue
+		}
+
+		parsed = append(parsed, mw)
+	}
+
+	return parsed, errs
+}
+
+func parseMaintenanceWindow(w v1alpha1.MaintenanceWindow) (*maintenanceWindow, error) {
+	if w.Name == "" {
+		return nil, errors.New("a name is required")
+	}
+
+	mw := &maintenanceWindow{name: w.Name, selector: labels.Everything()}
+	if w.Selector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(w.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector: %s", err)
+		}
+		mw.selector = sel
+	}
+
+	switch {
+	case w.Schedule != "" && (w.Start != nil || w.End != nil):
+		return nil, errors.New("a window has either a schedule or a start and end time, not both")
+	case w.Schedule != "":
+		sched, err := parseSchedule(w.Schedule)
+		if err != nil {
+			return nil, err
+		}
+
+		d, err := time.ParseDuration(w.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration: %s", err)
+		}
+
+		if d <= 0 || d > maxWindowDuration {
+			return nil, fmt.Errorf("duration should be positive and at most %s, got %s", maxWindowDuration, d)
+		}
+
+		mw.schedule, mw.duration = sched, d
+	case w.Start != nil && w.End != nil:
+		if !w.End.After(w.Start.Time) {
+			return nil, errors.New("end should be after start")
+		}
+
+		mw.start, mw.end = w.Start.Time, w.End.Time
+	default:
+		return nil, errors.New("a schedule and duration, or a start and end time are required")
+	}
+
+	return mw, nil
+}
+
+// active determines whether the window is active at the given time. It also
+// returns the time at which this next changes, which is zero when the window
+// won't change anymore.
+func (w *maintenanceWindow) active(now time.Time) (bool, time.Time) {
+	if w.schedule == nil {
+		switch {
+		case now.Before(w.start):
+			return false, w.start
+		case now.Before(w.end):
+			return true, w.end
+		}
+
+		return false, time.Time{}
+	}
+
+	// The window is active when it started less than its duration ago. The
+	// most recent start is used, so overlapping windows are extended.
+	minute := now.UTC().Truncate(time.Minute)
+	for t := minute; t.Add(w.duration).After(now); t = t.Add(-time.Minute) {
+		if w.schedule.matches(t) {
+			return true, t.Add(w.duration)
+		}
+	}
+
+	// Schedules which start less often than once a week are looked at
+	// again once the Operator has looked a week ahead.
+	horizon := minute.Add(maxWindowDuration)
+	for t := minute.Add(time.Minute); t.Before(horizon); t = t.Add(time.Minute) {
+		if w.schedule.matches(t) {
+			return false, t
+		}
+	}
+
+	return false, horizon
+}
+
+// schedule is a parsed cron expression. Every field is a bitmask of the values
+// it matches.
+type schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// When either the day of month or the day of week is restricted, a day
+	// matches when it matches either of them, like cron does.
+	restrictDays bool
+}
+
+// parseSchedule parses a cron expression with five fields. Every field is a
+// list of values, ranges or `*`, optionally with a step, like `*/15` or
+// `1-5`. Names of months and days aren't supported.
+func parseSchedule(expr string) (*schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule %q should have 5 fields, got %d", expr, len(fields))
+	}
+
+	sched := new(schedule)
+	for i, f := range []struct {
+		bits     *uint64
+		min, max int
+	}{
+		{&sched.minute, 0, 59},
+		{&sched.hour, 0, 23},
+		{&sched.dom, 1, 31},
+		{&sched.month, 1, 12},
+		{&sched.dow, 0, 7},
+	} {
+		bits, err := parseScheduleField(fields[i], f.min, f.max)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %s", expr, err)
+		}
+		*f.bits = bits
+	}
+
+	// Both 0 and 7 are Sunday.
+	if sched.dow&(1<<7) != 0 {
+		sched.dow |= 1
+	}
+
+	sched.restrictDays = !strings.HasPrefix(fields[2], "*") && !strings.HasPrefix(fields[4], "*")
+	return sched, nil
+}
+
+func parseScheduleField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rng, step = part[:i], s
+		}
+
+		lo, hi := min, max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value in %q", part)
+			}
+
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value in %q", part)
+				}
+			} else if step > 1 {
+				// A single value with a step, like `5/15`, runs up to the
+				// maximum.
+				hi = max
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q is out of range %d-%d", part, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// matches determines whether the schedule starts at the given minute, in UTC.
+func (s *schedule) matches(t time.Time) bool {
+	t = t.UTC()
+	if s.minute&(1<<uint(t.Minute())) == 0 ||
+		s.hour&(1<<uint(t.Hour())) == 0 ||
+		s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.restrictDays {
+		return dom || dow
+	}
+
+	return dom && dow
+}
+
+// evaluateMaintenanceWindows determines which maintenance windows of the
+// Monitor are active at the given time and records them in the given status.
+// It also returns the time at which the first of them changes next, which is
+// zero when none of them will.
+func evaluateMaintenanceWindows(obj *v1alpha1.Monitor, status *v1alpha1.MonitorStatus, now time.Time) ([]*maintenanceWindow, time.Time) {
+	status.ActiveMaintenanceWindows = nil
+	if len(obj.Spec.MaintenanceWindows) == 0 {
+		status.Conditions = removeCondition(status.Conditions, v1alpha1.ConditionMaintenanceWindowsValid)
+		return nil, time.Time{}
+	}
+
+	windows, errs := parseMaintenanceWindows(obj.Spec.MaintenanceWindows)
+	if len(errs) > 0 {
+		status.Conditions = setCondition(status.Conditions, newCondition(
+			v1alpha1.ConditionMaintenanceWindowsValid, false, reasonInvalidMaintenanceWindows,
+			"Ignoring invalid maintenance windows: "+strings.Join(errs, "; "),
+		))
+	} else {
+		status.Conditions = setCondition(status.Conditions, newCondition(
+			v1alpha1.ConditionMaintenanceWindowsValid, true, reasonValidMaintenanceWindows, "",
+		))
+	}
+
+	var active []*maintenanceWindow
+	var next time.Time
+	for _, w := range windows {
+		on, change := w.active(now)
+		if on {
+			active = append(active, w)
+			status.ActiveMaintenanceWindows = append(status.ActiveMaintenanceWindows, w.name)
+		}
+
+		if !change.IsZero() && (next.IsZero() || change.Before(next)) {
+			next = change
+		}
+	}
+
+	return active, next
+}
+
+// activeWindow returns the name of the first of the given active windows which
+// applies to an object with the given labels, or an empty string if there
+// is none.
+func activeWindow(windows []*maintenanceWindow, lbls map[string]string) string {
+	for _, w := range windows {
+		if w.selector.Matches(labels.Set(lbls)) {
+			return w.name
+		}
+	}
+
+	return ""
+}
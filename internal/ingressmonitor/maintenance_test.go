@@ -0,0 +1,289 @@
+package ingressmonitor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider/fake"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseSchedule(t *testing.T) {
+	at := func(s string) time.Time {
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatalf("Could not parse %s: %s", s, err)
+		}
+		return tm
+	}
+
+	for _, tc := range []struct {
+		name     string
+		expr     string
+		matches  []string
+		misses   []string
+		parseErr bool
+	}{
+		{
+			name:    "every day at 02:30",
+			expr:    "30 2 * * *",
+			matches: []string{"2021-03-01T02:30:00Z", "2021-03-06T02:30:00Z"},
+			misses:  []string{"2021-03-01T02:31:00Z", "2021-03-01T03:30:00Z"},
+		},
+		{
+			name:    "with steps, ranges and lists",
+			expr:    "*/15 9-17 * * 1-5",
+			matches: []string{"2021-03-01T09:00:00Z", "2021-03-05T17:45:00Z"},
+			misses:  []string{"2021-03-01T09:10:00Z", "2021-03-06T10:00:00Z", "2021-03-01T18:00:00Z"},
+		},
+		{
+			name:    "with Sunday as 7",
+			expr:    "0 4 * * 7",
+			matches: []string{"2021-03-07T04:00:00Z"},
+			misses:  []string{"2021-03-06T04:00:00Z"},
+		},
+		{
+			name:    "with a day of month and a day of week",
+			expr:    "0 0 1 * 0",
+			matches: []string{"2021-03-01T00:00:00Z", "2021-03-07T00:00:00Z"},
+			misses:  []string{"2021-03-02T00:00:00Z"},
+		},
+		{name: "with too few fields", expr: "0 0 * *", parseErr: true},
+		{name: "with an out of range value", expr: "60 0 * * *", parseErr: true},
+		{name: "with an invalid step", expr: "*/0 * * * *", parseErr: true},
+		{name: "with names", expr: "0 0 * * MON", parseErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sched, err := parseSchedule(tc.expr)
+			if tc.parseErr {
+				if err == nil {
+					t.Errorf("Expected an error parsing %q", tc.expr)
+				}
+				return
+			}
+			errEquals(t, nil, err)
+
+			for _, s := range tc.matches {
+				if !sched.matches(at(s)) {
+					t.Errorf("Expected %q to match %s", tc.expr, s)
+				}
+			}
+
+			for _, s := range tc.misses {
+				if sched.matches(at(s)) {
+					t.Errorf("Expected %q not to match %s", tc.expr, s)
+				}
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindow_Active(t *testing.T) {
+	now := time.Date(2021, 3, 1, 2, 45, 10, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name   string
+		window v1alpha1.MaintenanceWindow
+		active bool
+		next   time.Time
+	}{
+		{
+			name:   "inside a recurring window",
+			window: v1alpha1.MaintenanceWindow{Name: "nightly", Schedule: "30 2 * * *", Duration: "1h"},
+			active: true,
+			next:   time.Date(2021, 3, 1, 3, 30, 0, 0, time.UTC),
+		},
+		{
+			name:   "before a recurring window",
+			window: v1alpha1.MaintenanceWindow{Name: "nightly", Schedule: "30 2 * * *", Duration: "10m"},
+			next:   time.Date(2021, 3, 2, 2, 30, 0, 0, time.UTC),
+		},
+		{
+			name:   "before a one-off window",
+			window: v1alpha1.MaintenanceWindow{Name: "migration", Start: &metav1.Time{Time: now.Add(time.Hour)}, End: &metav1.Time{Time: now.Add(2 * time.Hour)}},
+			next:   now.Add(time.Hour),
+		},
+		{
+			name:   "inside a one-off window",
+			window: v1alpha1.MaintenanceWindow{Name: "migration", Start: &metav1.Time{Time: now.Add(-time.Hour)}, End: &metav1.Time{Time: now.Add(time.Hour)}},
+			active: true,
+			next:   now.Add(time.Hour),
+		},
+		{
+			name:   "after a one-off window",
+			window: v1alpha1.MaintenanceWindow{Name: "migration", Start: &metav1.Time{Time: now.Add(-2 * time.Hour)}, End: &metav1.Time{Time: now.Add(-time.Hour)}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w, err := parseMaintenanceWindow(tc.window)
+			errEquals(t, nil, err)
+
+			active, next := w.active(now)
+			if active != tc.active {
+				t.Errorf("Expected active to be %t, got %t", tc.active, active)
+			}
+
+			if !next.Equal(tc.next) {
+				t.Errorf("Expected the next change at %s, got %s", tc.next, next)
+			}
+		})
+	}
+
+	t.Run("with invalid windows", func(t *testing.T) {
+		for _, w := range []v1alpha1.MaintenanceWindow{
+			{Schedule: "30 2 * * *", Duration: "1h"},
+			{Name: "nightly", Schedule: "30 2 * * *"},
+			{Name: "nightly", Schedule: "30 2 * * *", Duration: "200h"},
+			{Name: "migration", Start: &metav1.Time{Time: now}},
+			{Name: "migration", Start: &metav1.Time{Time: now}, End: &metav1.Time{Time: now.Add(-time.Hour)}},
+			{Name: "both", Schedule: "30 2 * * *", Duration: "1h", Start: &metav1.Time{Time: now}},
+		} {
+			if _, err := parseMaintenanceWindow(w); err == nil {
+				t.Errorf("Expected an error for %#v", w)
+			}
+		}
+	})
+}
+
+func TestOperator_MaintenanceWindows(t *testing.T) {
+	newMaintenanceMonitor := func(window string) *v1alpha1.IngressMonitor {
+		im := newIngressMonitor()
+		im.Spec.MaintenanceWindow = window
+		im.Status.ID = "12345"
+		return im
+	}
+
+	newPausingOperator := func(pauseErr error) (*operatorWrapper, *fake.SimpleProvider) {
+		op := newOperator(t)
+		prov := &fake.SimpleProvider{
+			UpdateFunc: func(_ context.Context, id string, _ v1alpha1.MonitorTemplateSpec) (string, error) {
+				return id, nil
+			},
+			PauseFunc: func(context.Context, string) error {
+				return pauseErr
+			},
+			ResumeFunc: func(context.Context, string) error {
+				return nil
+			},
+		}
+		op.op.providerFactory.Register("simple", fake.FactoryFunc(prov))
+		return op, prov
+	}
+
+	t.Run("entering a maintenance window", func(t *testing.T) {
+		op, prov := newPausingOperator(nil)
+
+		im := newMaintenanceMonitor("migration")
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionPaused, v1.ConditionTrue, reasonMaintenanceWindow)
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionTrue, reasonSynced)
+		eventsEqual(t, op, "Normal Paused Paused monitor 12345 with provider simple during maintenance window migration")
+
+		if prov.UpdateCount != 1 || prov.PauseCount != 1 {
+			t.Errorf("Expected the monitor to be updated and paused, got %d updates and %d pauses", prov.UpdateCount, prov.PauseCount)
+		}
+
+		t.Run("during the window", func(t *testing.T) {
+			op.op.imInformer.GetIndexer().Update(im)
+			errEquals(t, nil, op.op.handleIngressMonitor(context.TODO(), getKey(t, im)))
+			eventsEqual(t, op)
+
+			if prov.UpdateCount != 1 || prov.PauseCount != 1 {
+				t.Errorf("Expected the paused monitor to be left alone, got %d updates and %d pauses", prov.UpdateCount, prov.PauseCount)
+			}
+		})
+	})
+
+	t.Run("leaving a maintenance window", func(t *testing.T) {
+		op, prov := newPausingOperator(nil)
+
+		im := newMaintenanceMonitor("")
+		im.Status.Conditions = setCondition(im.Status.Conditions, newCondition(
+			v1alpha1.ConditionPaused, true, reasonMaintenanceWindow, "Paused during maintenance window migration",
+		))
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionPaused, v1.ConditionFalse, reasonResumed)
+		eventsEqual(t, op, "Normal Resumed Resumed monitor 12345 with provider simple")
+
+		if prov.ResumeCount != 1 || prov.UpdateCount != 1 {
+			t.Errorf("Expected the monitor to be resumed and updated, got %d resumes and %d updates", prov.ResumeCount, prov.UpdateCount)
+		}
+	})
+
+	t.Run("with a failing pause", func(t *testing.T) {
+		op, _ := newPausingOperator(errors.New("provider unavailable"))
+
+		im := newMaintenanceMonitor("migration")
+		if err := op.handleIngressMonitor(t, im); err == nil {
+			t.Errorf("Expected an error pausing the monitor")
+		}
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionProviderSynced, v1.ConditionFalse, reasonSyncFailed)
+		if isPaused(im.Status.Conditions) {
+			t.Errorf("Expected the IngressMonitor not to be paused")
+		}
+	})
+
+	t.Run("selecting targets for a Monitor", func(t *testing.T) {
+		other := newIngress()
+		other.Name = "other-ingress"
+		other.UID = "other-ingress-uid"
+		other.Labels = map[string]string{"team": "gophers", "squad": "payments"}
+		other.Spec.TLS = nil
+		other.Spec.Rules = []networkingv1.IngressRule{{Host: "payments.example.com"}}
+
+		op := newOperator(t,
+			withIngresses(newIngress(), other),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		now := time.Now()
+		mon := newMonitor()
+		mon.Spec.MaintenanceWindows = []v1alpha1.MaintenanceWindow{
+			{
+				Name:     "migration",
+				Start:    &metav1.Time{Time: now.Add(-time.Hour)},
+				End:      &metav1.Time{Time: now.Add(time.Hour)},
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"squad": "operations"}},
+			},
+			{Name: "invalid", Schedule: "every night"},
+		}
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		windows := map[string]string{}
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		for _, im := range imList.Items {
+			windows[im.Labels[ingressLabel]] = im.Spec.MaintenanceWindow
+		}
+
+		strEquals(t, "migration", windows["go-ingress"], "window of the selected Ingress")
+		strEquals(t, "", windows["other-ingress"], "window of the other Ingress")
+
+		mon, err = op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		if len(mon.Status.ActiveMaintenanceWindows) != 1 || mon.Status.ActiveMaintenanceWindows[0] != "migration" {
+			t.Errorf("Expected the migration window to be active, got %v", mon.Status.ActiveMaintenanceWindows)
+		}
+		conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionMaintenanceWindowsValid, v1.ConditionFalse, reasonInvalidMaintenanceWindows)
+	})
+}
@@ -2,10 +2,13 @@ package ingressmonitor
 
 import (
 	"bytes"
+	"context"
 	"encoding/base32"
 	"errors"
 	"fmt"
 	"html/template"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,18 +21,27 @@ import (
 	"github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/informers/externalversions"
 	lv1alpha1 "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/listers/ingressmonitor/v1alpha1"
 
-	"k8s.io/api/extensions/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
-	ev1beta1 "k8s.io/client-go/listers/extensions/v1beta1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	av1 "k8s.io/client-go/listers/apps/v1"
+	cv1 "k8s.io/client-go/listers/core/v1"
+	nv1 "k8s.io/client-go/listers/networking/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
-	"k8s.io/kubernetes/pkg/apis/extensions"
 
 	"github.com/dchest/blake2b"
 	"github.com/sirupsen/logrus"
@@ -38,9 +50,29 @@ import (
 const (
 	monitorLabel     = "ingressmonitor.sphc.io/monitor"
 	ingressLabel     = "ingressmonitor.sphc.io/ingress"
+	serviceLabel     = "ingressmonitor.sphc.io/service"
+	httpRouteLabel   = "ingressmonitor.sphc.io/httproute"
 	ingressHostLabel = "ingressmonitor.sphc.io/ingress-path"
 )
 
+// providerFinalizer is the finalizer which is set on every IngressMonitor. It
+// is only removed once the monitor has been deleted with the provider, which
+// ensures no external checks are left behind.
+const providerFinalizer = "ingressmonitor.sphc.io/provider-cleanup"
+
+// lastSyncInterval is how often the LastSyncTime of an IngressMonitor is
+// refreshed when nothing else in its status changes.
+const lastSyncInterval = 5 * time.Minute
+
+const (
+	// providerIndex is the name of the Monitor index which links a Monitor to
+	// the Provider it references.
+	providerIndex = "provider"
+	// templateIndex is the name of the Monitor index which links a Monitor to
+	// the MonitorTemplate it references.
+	templateIndex = "template"
+)
+
 var (
 	errCouldNotSyncCache = errors.New("could not sync caches")
 	encoder              = base32.HexEncoding.WithPadding(base32.NoPadding)
@@ -51,23 +83,57 @@ type Operator struct {
 	kubeClient kubernetes.Interface
 	imClient   tv1alpha1.IngressmonitorV1alpha1Interface
 	metrics    *metrics.Metrics
+	recorder   record.EventRecorder
 
 	providerFactory provider.FactoryInterface
 
 	imInformer   cache.SharedIndexInformer
 	mInformer    cache.SharedIndexInformer
 	ingInformer  cache.SharedIndexInformer
+	svcInformer  cache.SharedIndexInformer
 	provInformer cache.SharedIndexInformer
 	mtInformer   cache.SharedIndexInformer
 
+	// The Deployments and StatefulSets are watched to follow the rollouts
+	// of the workloads serving the targets of Monitors. They're only set up
+	// through WatchRollouts.
+	deployInformer cache.SharedIndexInformer
+	stsInformer    cache.SharedIndexInformer
+
+	// The Gateway API informers are only set up when the Gateway API is
+	// installed in the cluster.
+	routeInformer cache.SharedIndexInformer
+	gwInformer    cache.SharedIndexInformer
+
 	informers []namedInformer
 
-	ingLister  ev1beta1.IngressLister
+	// ingressGV is the API version the Ingresses are watched with. This is
+	// used to reference the Ingresses from the IngressMonitors.
+	ingressGV schema.GroupVersion
+	// routeGV is the Gateway API version the HTTPRoutes are watched with. It's
+	// empty when the Gateway API isn't installed.
+	routeGV schema.GroupVersion
+
+	ingLister  nv1.IngressLister
+	svcLister  cv1.ServiceLister
+	mLister    lv1alpha1.MonitorLister
 	provLister lv1alpha1.ProviderLister
 	mtLister   lv1alpha1.MonitorTemplateLister
 
+	deployLister av1.DeploymentLister
+	stsLister    av1.StatefulSetLister
+	// stsRollouts tracks how long StatefulSets have been rolled out.
+	stsRollouts *rolloutClock
+
+	routeLister cache.GenericLister
+	gwLister    cache.GenericLister
+
 	monitorQueue        workqueue.RateLimitingInterface
 	ingressMonitorQueue workqueue.RateLimitingInterface
+
+	// leader is 1 while this replica runs the workers, it's only accessed
+	// atomically.
+	leader int32
 }
 
 type namedInformer struct {
@@ -78,7 +144,7 @@ type namedInformer struct {
 // NewOperator sets up a new IngressMonitor Operator which will watch for
 // providers and monitors.
 func NewOperator(
-	kc kubernetes.Interface, imc versioned.Interface,
+	kc kubernetes.Interface, imc versioned.Interface, dc dynamic.Interface,
 	namespace string, resync time.Duration,
 	providerFactory provider.FactoryInterface,
 	mtrcs *metrics.Metrics) (*Operator, error) {
@@ -86,31 +152,72 @@ func NewOperator(
 	// Register the scheme with the client so we can use it through the API
 	crdscheme.AddToScheme(scheme.Scheme)
 
+	// Events are recorded on the resources users manage themselves so they
+	// can see what happens with their monitors through `kubectl describe`.
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(logrus.Debugf)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kc.CoreV1().Events("")})
+
 	imInformer := externalversions.NewSharedInformerFactory(imc, resync).Ingressmonitor().V1alpha1()
 	k8sInformer := informers.NewSharedInformerFactory(kc, resync)
 
+	ingressGV, err := ingressGroupVersion(kc)
+	if err != nil {
+		return nil, fmt.Errorf("Could not discover the Ingress API version: %s", err)
+	}
+	logrus.WithFields(logrus.Fields{"version": ingressGV}).Info("Watching Ingresses")
+
+	ingInformer := k8sInformer.Networking().V1().Ingresses().Informer()
+	if ingressGV != networkingv1.SchemeGroupVersion {
+		ingInformer = newLegacyIngressInformer(kc, resync)
+	}
+
+	routeGV, err := gatewayGroupVersion(kc)
+	if err != nil {
+		return nil, fmt.Errorf("Could not discover the Gateway API version: %s", err)
+	}
+
 	op := &Operator{
 		kubeClient:          kc,
-		imClient:            imc.Ingressmonitor(),
+		imClient:            imc.IngressmonitorV1alpha1(),
 		providerFactory:     providerFactory,
 		monitorQueue:        workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Monitors"),
 		ingressMonitorQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "IngressMonitors"),
 		metrics:             mtrcs,
+		recorder:            eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "ingress-monitor"}),
 
 		imInformer:   imInformer.IngressMonitors().Informer(),
 		mInformer:    imInformer.Monitors().Informer(),
 		provInformer: imInformer.Providers().Informer(),
 		mtInformer:   imInformer.MonitorTemplates().Informer(),
 
-		ingInformer: k8sInformer.Extensions().V1beta1().Ingresses().Informer(),
+		ingInformer: ingInformer,
+		svcInformer: k8sInformer.Core().V1().Services().Informer(),
+		ingressGV:   ingressGV,
+		routeGV:     routeGV,
+	}
+
+	// Index the Monitors by the Providers and MonitorTemplates they reference
+	// so we can find them when one of these changes.
+	if err := op.mInformer.AddIndexers(cache.Indexers{
+		providerIndex: monitorProviderIndexFunc,
+		templateIndex: monitorTemplateIndexFunc,
+	}); err != nil {
+		return nil, err
 	}
 
 	// Add EventHandlers for all objects we want to track
 	op.imInformer.AddEventHandler(op)
 	op.mInformer.AddEventHandler(op)
+	op.ingInformer.AddEventHandler(op)
+	op.svcInformer.AddEventHandler(op)
+	op.provInformer.AddEventHandler(op)
+	op.mtInformer.AddEventHandler(op)
 
 	// set up listers
-	op.ingLister = ev1beta1.NewIngressLister(op.ingInformer.GetIndexer())
+	op.ingLister = nv1.NewIngressLister(op.ingInformer.GetIndexer())
+	op.svcLister = cv1.NewServiceLister(op.svcInformer.GetIndexer())
+	op.mLister = lv1alpha1.NewMonitorLister(op.mInformer.GetIndexer())
 	op.provLister = lv1alpha1.NewProviderLister(op.provInformer.GetIndexer())
 	op.mtLister = lv1alpha1.NewMonitorTemplateLister(op.mtInformer.GetIndexer())
 
@@ -118,10 +225,33 @@ func NewOperator(
 		{"IngressMonitor", op.imInformer},
 		{"Monitor", op.mInformer},
 		{"Ingress", op.ingInformer},
+		{"Service", op.svcInformer},
 		{"Provider", op.provInformer},
 		{"MonitorTemplate", op.mtInformer},
 	}
 
+	if routeGV.Empty() {
+		logrus.Info("The Gateway API isn't installed, not watching HTTPRoutes")
+		return op, nil
+	}
+
+	logrus.WithFields(logrus.Fields{"version": routeGV}).Info("Watching HTTPRoutes")
+	dynInformer := dynamicinformer.NewDynamicSharedInformerFactory(dc, resync)
+	routes := dynInformer.ForResource(routeGV.WithResource("httproutes"))
+	gateways := dynInformer.ForResource(routeGV.WithResource("gateways"))
+
+	op.routeInformer = routes.Informer()
+	op.gwInformer = gateways.Informer()
+	op.routeInformer.AddEventHandler(op)
+	op.gwInformer.AddEventHandler(op)
+	op.routeLister = routes.Lister()
+	op.gwLister = gateways.Lister()
+
+	op.informers = append(op.informers,
+		namedInformer{"HTTPRoute", op.routeInformer},
+		namedInformer{"Gateway", op.gwInformer},
+	)
+
 	return op, nil
 }
 
@@ -130,26 +260,59 @@ func (o *Operator) Run(stopCh <-chan struct{}) error {
 	defer o.monitorQueue.ShutDown()
 	defer o.ingressMonitorQueue.ShutDown()
 
+	if err := o.start(stopCh); err != nil {
+		return err
+	}
+
+	// The context is cancelled when the Operator stops, which cancels all
+	// calls which are still in flight.
+	ctx, cancel := stopContext(stopCh)
+	defer cancel()
+
+	// Without leader election, this is the only replica.
+	o.setLeader(true)
+	o.runWorkers(ctx)
+	logrus.Infof("Stopping IngressMonitor Operator")
+
+	return nil
+}
+
+// start connects to the cluster and starts the informers, after which the
+// caches are kept up to date until stopCh is closed.
+func (o *Operator) start(stopCh <-chan struct{}) error {
 	logrus.Infof("Starting IngressMonitor Operator")
 	if err := o.connectToCluster(stopCh); err != nil {
 		return err
 	}
 
 	logrus.Infof("Starting the informers")
-	if err := o.startInformers(stopCh); err != nil {
-		return err
-	}
+	return o.startInformers(stopCh)
+}
 
+// runWorkers processes the queues until the given context is cancelled.
+func (o *Operator) runWorkers(ctx context.Context) {
 	logrus.Infof("Starting the workers")
 	for i := 0; i < 4; i++ {
-		go wait.Until(runWorker(o.processNextIngressMonitor), time.Second, stopCh)
-		go wait.Until(runWorker(o.processNextMonitor), time.Second, stopCh)
+		go wait.Until(runWorker(ctx, o.processNextIngressMonitor), time.Second, ctx.Done())
+		go wait.Until(runWorker(ctx, o.processNextMonitor), time.Second, ctx.Done())
 	}
 
-	<-stopCh
-	logrus.Infof("Stopping IngressMonitor Operator")
+	<-ctx.Done()
+	logrus.Infof("Stopping the workers")
+}
 
-	return nil
+// stopContext returns a context which is cancelled when stopCh is closed.
+func stopContext(stopCh <-chan struct{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
 }
 
 func (o *Operator) connectToCluster(stopCh <-chan struct{}) error {
@@ -213,22 +376,22 @@ func (o *Operator) waitForCaches(stopCh <-chan struct{}) error {
 	return nil
 }
 
-func runWorker(queue func() bool) func() {
+func runWorker(ctx context.Context, queue func(context.Context) bool) func() {
 	return func() {
-		for queue() {
+		for queue(ctx) {
 		}
 	}
 }
 
-func (o *Operator) processNextIngressMonitor() bool {
-	return o.handleNextItem("IngressMonitors", o.ingressMonitorQueue, o.handleIngressMonitor)
+func (o *Operator) processNextIngressMonitor(ctx context.Context) bool {
+	return o.handleNextItem(ctx, "IngressMonitors", o.ingressMonitorQueue, o.handleIngressMonitor)
 }
 
-func (o *Operator) processNextMonitor() bool {
-	return o.handleNextItem("Monitors", o.monitorQueue, o.handleMonitor)
+func (o *Operator) processNextMonitor(ctx context.Context) bool {
+	return o.handleNextItem(ctx, "Monitors", o.monitorQueue, o.handleMonitor)
 }
 
-func (o *Operator) handleNextItem(name string, queue workqueue.RateLimitingInterface, handlerFunc func(string) error) bool {
+func (o *Operator) handleNextItem(ctx context.Context, name string, queue workqueue.RateLimitingInterface, handlerFunc func(context.Context, string) error) bool {
 	log := logrus.WithFields(logrus.Fields{
 		"queue_name": name,
 	})
@@ -238,6 +401,15 @@ func (o *Operator) handleNextItem(name string, queue workqueue.RateLimitingInter
 		return false
 	}
 
+	// The workers have been stopped while waiting for an item, for example
+	// because this replica lost its leadership. The item is left for the
+	// next worker.
+	if ctx.Err() != nil {
+		queue.Add(obj)
+		queue.Done(obj)
+		return false
+	}
+
 	// wrap this in a function so we can use defer to mark processing the item
 	// as done.
 	err := func(obj interface{}) error {
@@ -251,7 +423,22 @@ func (o *Operator) handleNextItem(name string, queue workqueue.RateLimitingInter
 			return nil
 		}
 
-		if err := handlerFunc(key); err != nil {
+		if err := handlerFunc(ctx, key); err != nil {
+			var rateLimited *provider.RateLimitError
+			switch {
+			case errors.As(err, &rateLimited):
+				// the provider told us when to come back, so don't retry
+				// any sooner.
+				queue.AddAfter(key, rateLimited.RetryAfter)
+			case errors.Is(err, provider.ErrInvalidSpec):
+				// retrying won't help, the item gets enqueued again once
+				// it changes.
+				queue.Forget(obj)
+			default:
+				// put the item back on the queue so it gets retried with an
+				// increasing delay.
+				queue.AddRateLimited(key)
+			}
 			return fmt.Errorf("Error handling '%s' in %s workqueue: %s", key, name, err)
 		}
 
@@ -287,6 +474,161 @@ func (o *Operator) enqueueMonitor(m *v1alpha1.Monitor) {
 	o.enqueueItem(o.monitorQueue, m)
 }
 
+// enqueueMonitorForIngressMonitor enqueues the Monitor which manages the given
+// IngressMonitor, if any.
+func (o *Operator) enqueueMonitorForIngressMonitor(im *v1alpha1.IngressMonitor) {
+	if name, ok := im.Labels[monitorLabel]; ok {
+		o.monitorQueue.AddRateLimited(im.Namespace + "/" + name)
+	}
+}
+
+// enqueueMonitorsForIngresses enqueues all the Monitors which select any of
+// the given Ingresses. Every Monitor is only enqueued once, even if it selects
+// multiple of the given Ingresses.
+func (o *Operator) enqueueMonitorsForIngresses(ings ...*networkingv1.Ingress) {
+	monitors := map[string]*v1alpha1.Monitor{}
+	for _, ing := range ings {
+		mons, err := o.mLister.Monitors(ing.Namespace).List(labels.Everything())
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"ingress_namespace": ing.Namespace,
+				"ingress_name":      ing.Name,
+			}).WithError(err).Error("Could not list Monitors for Ingress")
+			continue
+		}
+
+		for _, mon := range mons {
+			if monitorSelectsIngress(mon, ing) {
+				monitors[mon.Namespace+"/"+mon.Name] = mon
+			}
+		}
+	}
+
+	for _, mon := range monitors {
+		o.enqueueMonitor(mon)
+	}
+}
+
+// enqueueMonitorsForServices enqueues all the Monitors which select any of the
+// given Services.
+func (o *Operator) enqueueMonitorsForServices(svcs ...*v1.Service) {
+	monitors := map[string]*v1alpha1.Monitor{}
+	for _, svc := range svcs {
+		mons, err := o.mLister.Monitors(svc.Namespace).List(labels.Everything())
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"service_namespace": svc.Namespace,
+				"service_name":      svc.Name,
+			}).WithError(err).Error("Could not list Monitors for Service")
+			continue
+		}
+
+		for _, mon := range mons {
+			if monitorSelectsService(mon, svc) {
+				monitors[mon.Namespace+"/"+mon.Name] = mon
+			}
+		}
+	}
+
+	for _, mon := range monitors {
+		o.enqueueMonitor(mon)
+	}
+}
+
+// enqueueMonitorsForRoutes enqueues all the Monitors which select any of the
+// given HTTPRoutes.
+func (o *Operator) enqueueMonitorsForRoutes(routes ...*unstructured.Unstructured) {
+	monitors := map[string]*v1alpha1.Monitor{}
+	for _, route := range routes {
+		mons, err := o.mLister.Monitors(route.GetNamespace()).List(labels.Everything())
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"httproute_namespace": route.GetNamespace(),
+				"httproute_name":      route.GetName(),
+			}).WithError(err).Error("Could not list Monitors for HTTPRoute")
+			continue
+		}
+
+		for _, mon := range mons {
+			if monitorSelectsRoute(mon, route) {
+				monitors[mon.Namespace+"/"+mon.Name] = mon
+			}
+		}
+	}
+
+	for _, mon := range monitors {
+		o.enqueueMonitor(mon)
+	}
+}
+
+// enqueueMonitorsForGateway enqueues all the Monitors which select
+// HTTPRoutes. HTTPRoutes can be attached to Gateways in other namespaces, so
+// every Monitor selecting HTTPRoutes could be affected by a Gateway change.
+func (o *Operator) enqueueMonitorsForGateway() {
+	mons, err := o.mLister.List(labels.Everything())
+	if err != nil {
+		logrus.WithError(err).Error("Could not list Monitors for Gateway")
+		return
+	}
+
+	for _, mon := range mons {
+		if mon.Spec.HTTPRoutes != nil {
+			o.enqueueMonitor(mon)
+		}
+	}
+}
+
+// enqueueMonitorsForUnstructured enqueues the Monitors affected by a change
+// to one of the Gateway API objects.
+func (o *Operator) enqueueMonitorsForUnstructured(objs ...*unstructured.Unstructured) {
+	switch objs[0].GetKind() {
+	case "HTTPRoute":
+		o.enqueueMonitorsForRoutes(objs...)
+	case "Gateway":
+		o.enqueueMonitorsForGateway()
+	}
+}
+
+// enqueueMonitorsByIndex enqueues all the Monitors which reference the given
+// object through the specified index.
+func (o *Operator) enqueueMonitorsByIndex(index string, obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+
+	mons, err := o.mInformer.GetIndexer().ByIndex(index, key)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"index": index,
+			"key":   key,
+		}).WithError(err).Error("Could not get Monitors from index")
+		return
+	}
+
+	for _, mon := range mons {
+		o.enqueueMonitor(mon.(*v1alpha1.Monitor))
+	}
+}
+
+func monitorProviderIndexFunc(obj interface{}) ([]string, error) {
+	mon, ok := obj.(*v1alpha1.Monitor)
+	if !ok {
+		return nil, nil
+	}
+
+	return []string{mon.Namespace + "/" + mon.Spec.Provider.Name}, nil
+}
+
+func monitorTemplateIndexFunc(obj interface{}) ([]string, error) {
+	mon, ok := obj.(*v1alpha1.Monitor)
+	if !ok {
+		return nil, nil
+	}
+
+	return []string{mon.Namespace + "/" + mon.Spec.Template.Name}, nil
+}
+
 // OnAdd handles adding of IngressMonitors and Ingresses and sets up the
 // appropriate monitor with the configured providers.
 func (o *Operator) OnAdd(obj interface{}) {
@@ -297,6 +639,20 @@ func (o *Operator) OnAdd(obj interface{}) {
 		o.enqueueIngressMonitor(obj)
 	case *v1alpha1.Monitor:
 		o.enqueueMonitor(obj)
+	case *networkingv1.Ingress:
+		o.enqueueMonitorsForIngresses(obj)
+	case *v1.Service:
+		o.enqueueMonitorsForServices(obj)
+	case *unstructured.Unstructured:
+		o.enqueueMonitorsForUnstructured(obj)
+	case *appsv1.Deployment:
+		o.enqueueMonitorsForWorkload(obj.Namespace, "Deployment", obj.Name)
+	case *appsv1.StatefulSet:
+		o.enqueueMonitorsForWorkload(obj.Namespace, "StatefulSet", obj.Name)
+	case *v1alpha1.Provider:
+		o.enqueueMonitorsByIndex(providerIndex, obj)
+	case *v1alpha1.MonitorTemplate:
+		o.enqueueMonitorsByIndex(templateIndex, obj)
 	}
 }
 
@@ -305,32 +661,93 @@ func (o *Operator) OnAdd(obj interface{}) {
 func (o *Operator) OnUpdate(old, new interface{}) {
 	switch obj := new.(type) {
 	case *v1alpha1.IngressMonitor:
-		o.enqueueIngressMonitor(obj)
+		oldIM := old.(*v1alpha1.IngressMonitor)
+		if ingressMonitorChanged(oldIM, obj) {
+			o.enqueueIngressMonitor(obj)
+		}
+
+		// The Monitor keeps track of how many of its IngressMonitors are
+		// Ready and paused, make sure it's up to date.
+		if isReady(oldIM.Status.Conditions) != isReady(obj.Status.Conditions) ||
+			isPaused(oldIM.Status.Conditions) != isPaused(obj.Status.Conditions) {
+			o.enqueueMonitorForIngressMonitor(obj)
+		}
 	case *v1alpha1.Monitor:
+		oldMon := old.(*v1alpha1.Monitor)
+
+		// Status updates are done by the Operator itself, there's no need to
+		// sync again for those.
+		if oldMon.ResourceVersion != obj.ResourceVersion && oldMon.Generation == obj.Generation &&
+			reflect.DeepEqual(oldMon.Spec, obj.Spec) &&
+			oldMon.Annotations[pausedAnnotation] == obj.Annotations[pausedAnnotation] {
+			return
+		}
+
 		o.enqueueMonitor(obj)
+	case *networkingv1.Ingress:
+		oldIng := old.(*networkingv1.Ingress)
+
+		// Periodic resyncs send the same object, the Monitors take care of
+		// their own resync so there's no need to do it twice.
+		if oldIng.ResourceVersion == obj.ResourceVersion {
+			return
+		}
+
+		// Both the old and new labels are used to find the Monitors, this way
+		// Monitors that don't select the Ingress anymore can clean up.
+		o.enqueueMonitorsForIngresses(oldIng, obj)
+	case *v1.Service:
+		oldSvc := old.(*v1.Service)
+		if oldSvc.ResourceVersion == obj.ResourceVersion {
+			return
+		}
+
+		// The LoadBalancer address is only known once the Service has been
+		// provisioned, which is reported through a status update.
+		o.enqueueMonitorsForServices(oldSvc, obj)
+	case *unstructured.Unstructured:
+		oldObj := old.(*unstructured.Unstructured)
+		if oldObj.GetResourceVersion() == obj.GetResourceVersion() {
+			return
+		}
+
+		o.enqueueMonitorsForUnstructured(oldObj, obj)
+	case *appsv1.Deployment:
+		if workloadChanged(old, obj) {
+			o.enqueueMonitorsForWorkload(obj.Namespace, "Deployment", obj.Name)
+		}
+	case *appsv1.StatefulSet:
+		if workloadChanged(old, obj) {
+			o.enqueueMonitorsForWorkload(obj.Namespace, "StatefulSet", obj.Name)
+		}
+	case *v1alpha1.Provider:
+		if old.(*v1alpha1.Provider).ResourceVersion != obj.ResourceVersion {
+			o.enqueueMonitorsByIndex(providerIndex, obj)
+		}
+	case *v1alpha1.MonitorTemplate:
+		if old.(*v1alpha1.MonitorTemplate).ResourceVersion != obj.ResourceVersion {
+			o.enqueueMonitorsByIndex(templateIndex, obj)
+		}
 	}
 }
 
 // OnDelete handles deletion of IngressMonitors and Ingresses and deletes
 // monitors from the configured providers.
 func (o *Operator) OnDelete(obj interface{}) {
+	// When a delete event is missed, the informer sends us the last known
+	// state of the object instead.
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
 	switch obj := obj.(type) {
 	case *v1alpha1.IngressMonitor:
+		// The monitor has already been removed from the provider when the
+		// finalizer was handled in handleIngressMonitor.
 		o.metrics.DeleteIngressMonitor(ingressMonitorMetric(obj, nil))
-
-		cl, err := o.providerFactory.From(obj.Spec.Provider)
-		if err != nil {
-			logDeleteErr("ingress_monitor", obj.Namespace, obj.Name, err, "could not get provider for IngressMonitor")
-			return
-		}
-
-		if err := cl.Delete(obj.Status.ID); err != nil {
-			logDeleteErr("ingress_monitor", obj.Namespace, obj.Name, err, "could not delete IngressMonitor")
-			return
-		}
 	case *v1alpha1.Monitor:
 		imList, err := o.imClient.IngressMonitors(obj.Namespace).
-			List(listOptions(map[string]string{monitorLabel: obj.Name}))
+			List(context.TODO(), listOptions(map[string]string{monitorLabel: obj.Name}))
 		if err != nil {
 			logDeleteErr("monitor", obj.Namespace, obj.Name, err, "could not list IngressMonitors for Monitor")
 			return
@@ -345,11 +762,39 @@ func (o *Operator) OnDelete(obj interface{}) {
 			})
 			ll.Debug("Deleting IngressMonitor")
 			if err := o.imClient.IngressMonitors(obj.Namespace).
-				Delete(im.Name, &metav1.DeleteOptions{}); err != nil {
+				Delete(context.TODO(), im.Name, metav1.DeleteOptions{}); err != nil {
 				ll.WithError(err).Error("could not delete IngressMonitor for Monitor")
 			}
 		}
+	case *networkingv1.Ingress:
+		o.enqueueMonitorsForIngresses(obj)
+	case *v1.Service:
+		o.enqueueMonitorsForServices(obj)
+	case *unstructured.Unstructured:
+		o.enqueueMonitorsForUnstructured(obj)
+	case *appsv1.Deployment:
+		o.enqueueMonitorsForWorkload(obj.Namespace, "Deployment", obj.Name)
+	case *appsv1.StatefulSet:
+		o.enqueueMonitorsForWorkload(obj.Namespace, "StatefulSet", obj.Name)
+	case *v1alpha1.Provider:
+		o.enqueueMonitorsByIndex(providerIndex, obj)
+	case *v1alpha1.MonitorTemplate:
+		o.enqueueMonitorsByIndex(templateIndex, obj)
+	}
+}
+
+// ingressMonitorChanged determines if the IngressMonitor needs to be synced
+// with the provider. Status updates are done by the Operator itself after a
+// sync, syncing again on those would keep the IngressMonitor in a loop.
+func ingressMonitorChanged(old, new *v1alpha1.IngressMonitor) bool {
+	// periodic resync
+	if old.ResourceVersion == new.ResourceVersion {
+		return true
 	}
+
+	return !reflect.DeepEqual(old.Spec, new.Spec) ||
+		!reflect.DeepEqual(old.DeletionTimestamp, new.DeletionTimestamp) ||
+		old.Annotations[pausedAnnotation] != new.Annotations[pausedAnnotation]
 }
 
 func logDeleteErr(prefix, ns, name string, err error, msg string) {
@@ -361,7 +806,7 @@ func logDeleteErr(prefix, ns, name string, err error, msg string) {
 
 // handleIngressMonitor handles IngressMonitors in a way that it knows how to
 // deal with creating and updating resources.
-func (o *Operator) handleIngressMonitor(key string) (err error) {
+func (o *Operator) handleIngressMonitor(ctx context.Context, key string) (err error) {
 	item, exists, err := o.imInformer.GetIndexer().GetByKey(key)
 	if err != nil {
 		return err
@@ -372,85 +817,346 @@ func (o *Operator) handleIngressMonitor(key string) (err error) {
 		return nil
 	}
 
-	obj := item.(*v1alpha1.IngressMonitor)
+	// don't modify the object in the cache
+	obj := item.(*v1alpha1.IngressMonitor).DeepCopy()
 
 	// XXX handle indexer errors
 	defer func() {
 		o.metrics.SyncIngressMonitor(ingressMonitorMetric(obj, err))
 	}()
 
+	if obj.DeletionTimestamp != nil {
+		return o.finalizeIngressMonitor(ctx, obj)
+	}
+
+	// Ensure the finalizer is present before we create anything with the
+	// provider, otherwise we could lose track of the monitor.
+	if !hasString(obj.Finalizers, providerFinalizer) {
+		obj.Finalizers = append(obj.Finalizers, providerFinalizer)
+		if obj, err = o.imClient.IngressMonitors(obj.Namespace).Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("Could not add finalizer: %s", err)
+		}
+	}
+
 	cl, err := o.providerFactory.From(obj.Spec.Provider)
 	if err != nil {
-		return fmt.Errorf("Error fetching provider '%s': %s", obj.Spec.Provider.Type, err)
+		err = fmt.Errorf("Error fetching provider '%s': %s", obj.Spec.Provider.Type, err)
+		o.recordEvent(obj, v1.EventTypeWarning, eventReasonProviderError, "%s", err)
+		return o.recordIngressMonitorSync(ctx, obj, err,
+			newCondition(v1alpha1.ConditionCredentialsResolved, false, reasonProviderUnavailable, err.Error()),
+		)
+	}
+
+	// The credentials of the check are resolved every sync, so changes to
+	// their Secrets are picked up with the next resync.
+	tmpl, err := o.resolveTemplate(ctx, obj)
+	if err != nil {
+		return o.recordIngressMonitorSync(ctx, obj, err,
+			newCondition(v1alpha1.ConditionCredentialsResolved, false, reasonSecretNotFound, err.Error()),
+		)
+	}
+
+	pctx, cancel := providerContext(ctx, obj)
+	defer cancel()
+
+	credsCondition := newCondition(v1alpha1.ConditionCredentialsResolved, true, reasonResolved, "")
+
+	// Providers might resume a check when it's updated, so paused checks are
+	// left alone until they're resumed, after which they're synced with the
+	// spec again.
+	reason, why := pauseReason(obj)
+	if isPaused(obj.Status.Conditions) {
+		if reason != "" {
+			refreshPaused(obj, reason, why)
+			return o.recordIngressMonitorSync(ctx, obj, nil, credsCondition)
+		}
+
+		if err := o.resumeMonitor(pctx, cl, obj); err != nil {
+			o.recordEvent(obj, v1.EventTypeWarning, eventReasonProviderError, "%s", err)
+			return o.recordIngressMonitorSync(ctx, obj, err,
+				credsCondition,
+				newCondition(v1alpha1.ConditionProviderSynced, false, syncFailedReason(err), err.Error()),
+			)
+		}
+	}
+
+	// A monitor which has never been synced might have been created without
+	// its ID making it into the status, it's adopted instead of creating a
+	// duplicate.
+	existing := obj.Status.ID
+	if existing == "" && !isProviderSynced(obj.Status.Conditions) {
+		existing = o.adoptableMonitor(pctx, cl, obj, tmpl)
 	}
 
 	var id string
-	if obj.Status.ID != "" {
-		// This object hasn't been created yet, do so!
-		id, err = cl.Update(obj.Status.ID, obj.Spec.Template)
+	if existing != "" {
+		id, err = cl.Update(pctx, existing, tmpl)
+		switch {
+		case errors.Is(err, provider.ErrNoChange):
+			id, err = existing, nil
+		case errors.Is(err, provider.ErrNotFound):
+			// The monitor has been removed from the provider, the operator
+			// ensures it's present so we create a new one.
+			id, err = cl.Create(pctx, tmpl)
+		}
 	} else {
-		id, err = cl.Create(obj.Spec.Template)
+		// This object hasn't been created yet, do so!
+		id, err = cl.Create(pctx, tmpl)
 	}
 
-	if err != nil {
-		return err
+	// Providers set up the monitor without the assertions they can't
+	// express. The monitor is in sync, so they're reported instead of
+	// retried.
+	var unsupported *provider.UnsupportedAssertionsError
+	if errors.As(err, &unsupported) {
+		err = nil
 	}
 
-	// The ID has changed, update the status. This could happen when the test
-	// has been removed from the provider. The operator ensures that the test
-	// will be present, and thus create a new one.
-	if obj.Status.ID != id {
-		obj.Status.ID = id
-		_, err = o.imClient.IngressMonitors(obj.Namespace).Update(obj)
+	if errors.Is(err, provider.ErrUnsupportedType) && canCheckCertificate(obj) {
+		// The Operator checks the certificate itself when the provider
+		// can't.
+		return o.checkCertificate(ctx, obj, err, credsCondition)
+	} else if errors.Is(err, provider.ErrUnauthorized) {
+		o.recordEvent(obj, v1.EventTypeWarning, eventReasonProviderError, "Provider %s rejected the credentials: %s", obj.Spec.Provider.Type, err)
+		return o.recordIngressMonitorSync(ctx, obj, err,
+			newCondition(v1alpha1.ConditionProviderSynced, false, reasonSyncFailed, err.Error()),
+			newCondition(v1alpha1.ConditionCredentialsResolved, false, reasonUnauthorized, err.Error()),
+		)
+	} else if err != nil {
+		o.recordEvent(obj, v1.EventTypeWarning, eventReasonProviderError, "Could not sync monitor with provider %s: %s", obj.Spec.Provider.Type, err)
+		return o.recordIngressMonitorSync(ctx, obj, err,
+			credsCondition,
+			newCondition(v1alpha1.ConditionProviderSynced, false, syncFailedReason(err), err.Error()),
+		)
+	}
+
+	// The ID might have changed. This could happen when the test has been
+	// removed from the provider. The operator ensures that the test will be
+	// present, and thus create a new one.
+	switch {
+	case obj.Status.ID == "" && existing != "" && id == existing:
+		o.recordEvent(obj, v1.EventTypeNormal, eventReasonAdopted, "Adopted existing monitor %s with provider %s", id, obj.Spec.Provider.Type)
+	case obj.Status.ID == "":
+		o.recordEvent(obj, v1.EventTypeNormal, eventReasonCreated, "Created monitor %s with provider %s", id, obj.Spec.Provider.Type)
+	case obj.Status.ID != id:
+		o.recordEvent(obj, v1.EventTypeNormal, eventReasonRecreated, "Monitor %s was not found with provider %s, recreated it as %s", obj.Status.ID, obj.Spec.Provider.Type, id)
+	case obj.Status.ObservedGeneration != obj.Generation:
+		// Only record spec changes, not every resync.
+		o.recordEvent(obj, v1.EventTypeNormal, eventReasonUpdated, "Updated monitor %s with provider %s", id, obj.Spec.Provider.Type)
+	}
+	obj.Status.ID = id
+	o.recordAssertions(obj, unsupported)
+
+	if reason != "" {
+		if err := o.pauseMonitor(pctx, cl, obj, reason, why); err != nil {
+			o.recordEvent(obj, v1.EventTypeWarning, eventReasonProviderError, "%s", err)
+			return o.recordIngressMonitorSync(ctx, obj, err,
+				credsCondition,
+				newCondition(v1alpha1.ConditionProviderSynced, false, syncFailedReason(err), err.Error()),
+			)
+		}
 	}
 
-	return err
+	return o.recordIngressMonitorSync(ctx, obj, nil,
+		credsCondition,
+		newCondition(v1alpha1.ConditionProviderSynced, true, reasonSynced, ""),
+	)
+}
+
+// recordIngressMonitorSync records the outcome of a sync with the provider in
+// the status of the IngressMonitor and writes it to the API. The Ready
+// condition is derived from the outcome. The given sync error is returned so
+// it can be used as the result of the sync.
+func (o *Operator) recordIngressMonitorSync(ctx context.Context, obj *v1alpha1.IngressMonitor, syncErr error, conds ...v1alpha1.Condition) error {
+	status := &obj.Status
+	for _, cond := range conds {
+		status.Conditions = setCondition(status.Conditions, cond)
+	}
+
+	ready := newCondition(v1alpha1.ConditionReady, true, reasonSynced, "")
+	status.LastError = ""
+	if syncErr != nil {
+		ready = newCondition(v1alpha1.ConditionReady, false, conds[len(conds)-1].Reason, syncErr.Error())
+		status.LastError = syncErr.Error()
+	}
+	status.Conditions = setCondition(status.Conditions, ready)
+
+	now := metav1.Now()
+	status.LastSyncTime = &now
+	status.ObservedGeneration = obj.Generation
+	if name, ok := obj.Labels[ingressLabel]; ok {
+		status.IngressName = name
+	}
+	if name, ok := obj.Labels[serviceLabel]; ok {
+		status.ServiceName = name
+	}
+	if name, ok := obj.Labels[httpRouteLabel]; ok {
+		status.HTTPRouteName = name
+	}
+
+	if !o.ingressMonitorStatusChanged(obj) {
+		return syncErr
+	}
+
+	if _, err := o.imClient.IngressMonitors(obj.Namespace).UpdateStatus(ctx, obj, metav1.UpdateOptions{}); err != nil && syncErr == nil {
+		return fmt.Errorf("Could not update status for IngressMonitor %s:%s: %s", obj.Namespace, obj.Name, err)
+	}
+
+	return syncErr
+}
+
+// ingressMonitorStatusChanged reports if the status of the IngressMonitor
+// differs from the one in the cache. A changed LastSyncTime only counts once
+// every lastSyncInterval, so resyncs which don't change anything don't write
+// the status every time.
+func (o *Operator) ingressMonitorStatusChanged(obj *v1alpha1.IngressMonitor) bool {
+	item, exists, err := o.imInformer.GetIndexer().Get(obj)
+	if err != nil || !exists {
+		return true
+	}
+
+	cached := item.(*v1alpha1.IngressMonitor).Status
+	if cached.LastSyncTime == nil || obj.Status.LastSyncTime.Sub(cached.LastSyncTime.Time) >= lastSyncInterval {
+		return true
+	}
+
+	status := obj.Status
+	status.LastSyncTime = cached.LastSyncTime
+	return !reflect.DeepEqual(&cached, &status)
+}
+
+// recordAssertions records whether the provider could express all the
+// assertions of the IngressMonitor in its status. The condition is only set
+// for checks with assertions, and a Warning Event is recorded every time the
+// unsupported assertions change.
+func (o *Operator) recordAssertions(obj *v1alpha1.IngressMonitor, unsupported *provider.UnsupportedAssertionsError) {
+	http := obj.Spec.Template.HTTP
+
+	switch {
+	case unsupported != nil:
+		cond := newCondition(v1alpha1.ConditionAssertionsSupported, false, reasonUnsupportedAssertions, unsupported.Error())
+		if prev := getCondition(obj.Status.Conditions, cond.Type); prev == nil || prev.Message != cond.Message {
+			o.recordEvent(obj, v1.EventTypeWarning, eventReasonUnsupportedAssertions, "%s", unsupported)
+		}
+		obj.Status.Conditions = setCondition(obj.Status.Conditions, cond)
+	case http != nil && len(http.Assertions) > 0:
+		obj.Status.Conditions = setCondition(obj.Status.Conditions,
+			newCondition(v1alpha1.ConditionAssertionsSupported, true, reasonAssertionsSupported, ""),
+		)
+	default:
+		obj.Status.Conditions = removeCondition(obj.Status.Conditions, v1alpha1.ConditionAssertionsSupported)
+	}
+}
+
+// finalizeIngressMonitor deletes the monitor for the given IngressMonitor with
+// the provider and removes the finalizer once that has succeeded. When the
+// provider can't be reached, an error is returned so the IngressMonitor is
+// retried later on.
+func (o *Operator) finalizeIngressMonitor(ctx context.Context, obj *v1alpha1.IngressMonitor) error {
+	if !hasString(obj.Finalizers, providerFinalizer) {
+		return nil
+	}
+
+	// Without an ID, the monitor has never been created with the provider.
+	if obj.Status.ID != "" {
+		cl, err := o.providerFactory.From(obj.Spec.Provider)
+		if err != nil {
+			err = fmt.Errorf("Error fetching provider '%s': %s", obj.Spec.Provider.Type, err)
+			o.recordEvent(obj, v1.EventTypeWarning, eventReasonProviderError, "%s", err)
+			return err
+		}
+
+		pctx, cancel := providerContext(ctx, obj)
+		defer cancel()
+
+		err = cl.Delete(pctx, obj.Status.ID)
+		if err != nil && !errors.Is(err, provider.ErrNotFound) {
+			err = fmt.Errorf("Could not delete monitor '%s' with provider: %w", obj.Status.ID, err)
+			o.recordEvent(obj, v1.EventTypeWarning, eventReasonProviderError, "%s", err)
+			return err
+		}
+
+		o.recordEvent(obj, v1.EventTypeNormal, eventReasonDeleted, "Deleted monitor %s from provider %s", obj.Status.ID, obj.Spec.Provider.Type)
+	}
+
+	obj.Finalizers = removeString(obj.Finalizers, providerFinalizer)
+	if _, err := o.imClient.IngressMonitors(obj.Namespace).Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("Could not remove finalizer: %s", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"ingress_monitor_namespace": obj.Namespace,
+		"ingress_monitor_name":      obj.Name,
+	}).Debug("Deleted monitor with provider")
+
+	return nil
+}
+
+// syncFailedReason returns the reason for the ProviderSynced condition when a
+// sync with the provider failed with the given error.
+func syncFailedReason(err error) string {
+	switch {
+	case errors.Is(err, provider.ErrRateLimited):
+		return reasonRateLimited
+	case errors.Is(err, provider.ErrUnsupportedType):
+		return reasonUnsupportedType
+	case errors.Is(err, provider.ErrInvalidSpec):
+		return reasonInvalidSpec
+	}
+
+	return reasonSyncFailed
+}
+
+// providerContext returns a context which expires once the timeout of the
+// provider of the given IngressMonitor has passed, so a provider which doesn't
+// respond can't block a worker.
+func providerContext(ctx context.Context, obj *v1alpha1.IngressMonitor) (context.Context, context.CancelFunc) {
+	timeout, err := provider.Timeout(obj.Spec.Provider.ProviderSpec)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ingress_monitor_namespace": obj.Namespace,
+			"ingress_monitor_name":      obj.Name,
+		}).WithError(err).Warnf("Invalid provider timeout, using %s", timeout)
+	}
+
+	return context.WithTimeout(ctx, timeout)
 }
 
 // garbgageCollectMonitors finds all IngressMonitors that are linked to a
 // specific Monitor which shouldn't be configured in the cluster anymore.
-// It does this by fetching all Ingresses which should currently be set up for
+// It does this by fetching all targets which should currently be set up for
 // the monitor and then fetching the IngressMonitors which are linked to the
 // specified Monitor.
-// If one of the monitors isn't linked to the Ingress, it gets marked for
+// If one of the monitors isn't linked to a target, it gets marked for
 // deletion.
-func (o *Operator) garbageCollectMonitors(obj *v1alpha1.Monitor) error {
-	ingLabels, err := metav1.LabelSelectorAsSelector(obj.Spec.Selector)
+func (o *Operator) garbageCollectMonitors(ctx context.Context, obj *v1alpha1.Monitor) error {
+	sel, err := o.selectedTargets(obj)
 	if err != nil {
-		return fmt.Errorf("Could not create label selector for %s:%s: %s", obj.Namespace, obj.Name, err)
-	}
-
-	ingressList, err := o.ingLister.Ingresses(obj.Namespace).List(ingLabels)
-	if err != nil {
-		return fmt.Errorf("Could not list Ingresses: %s", err)
+		return err
 	}
 
 	// We'll calculate all the IngressMonitors that shouldn't be tracked
 	// anymore and delete them. We can do this by fetching all
 	// IngressMonitors where the owner is this Monitor, go over them all and
-	// see if there are any where the Ingress Owner isn't in the new Ingress
-	// List.
+	// see if there are any which don't belong to one of the new targets.
 	imLabels := labels.SelectorFromSet(map[string]string{monitorLabel: obj.Name})
 	cache.ListAllByNamespace(o.imInformer.GetIndexer(), obj.Namespace, imLabels, func(imObj interface{}) {
 		im := imObj.(*v1alpha1.IngressMonitor)
 		var isActive bool
 
-		// Go through all newly selected Ingresses and see if this
-		// IngressMonitor is active for any of them. We do this by first
-		// validating if it's controlled by the Ingress, and if so we check if
-		// it matches any of the rules. Ingresses might change which means a
-		// specific rule can be dropped. We need to GC that.
-		for _, ing := range ingressList {
-			if metav1.IsControlledBy(im, ing) {
-				for _, rule := range ing.Spec.Rules {
-					if rule.Host == im.Labels[ingressHostLabel] {
-						isActive = true
-					}
-				}
+		// Go through all newly selected targets and see if this
+		// IngressMonitor is active for any of them. We do this by validating
+		// if it's controlled by the owner of the target and if it's set up
+		// for the same host and path, which are both part of the name.
+		// Ingresses might change which means a specific rule or path can be
+		// dropped. We need to GC that.
+		for _, t := range sel.targets {
+			if metav1.IsControlledBy(im, t.owner) && im.Name == t.name {
+				isActive = true
 			}
 		}
 
-		// The IngressMonitor doesn't appear in any newly selected Ingress
+		// The IngressMonitor doesn't appear in any newly selected target
 		// anymore, which means it's ready for GarbageCollection. Delete the
 		// IngressMonitor Resource from the server, which will then trigger a
 		// reconciliation to take care of actually removing the monitor with the
@@ -462,16 +1168,19 @@ func (o *Operator) garbageCollectMonitors(obj *v1alpha1.Monitor) error {
 			})
 			ll.Debug("Deleting IngressMonitor with GC")
 			if err := o.imClient.IngressMonitors(im.Namespace).
-				Delete(im.Name, &metav1.DeleteOptions{}); err != nil {
+				Delete(ctx, im.Name, metav1.DeleteOptions{}); err != nil {
 				ll.WithError(err).Error("Could not delete IngressMonitor")
+				return
 			}
+
+			o.recordEvent(im, v1.EventTypeNormal, eventReasonGarbageCollected, "Host %s is no longer selected by Monitor %s, deleting IngressMonitor %s", im.Labels[ingressHostLabel], obj.Name, im.Name)
 		}
 	})
 
 	return nil
 }
 
-func (o *Operator) handleMonitor(key string) error {
+func (o *Operator) handleMonitor(ctx context.Context, key string) error {
 	item, exists, err := o.mInformer.GetIndexer().GetByKey(key)
 	if err != nil {
 		return err
@@ -483,135 +1192,354 @@ func (o *Operator) handleMonitor(key string) error {
 	}
 
 	obj := item.(*v1alpha1.Monitor)
-	if err := o.garbageCollectMonitors(obj); err != nil {
+	status := obj.Status.DeepCopy()
+	status.ObservedGeneration = obj.Generation
+
+	// An invalid selector can only be fixed by updating the Monitor, which
+	// enqueues it again, so there's no need to retry.
+	if err := validateSelectors(obj); err != nil {
+		status.Conditions = setCondition(status.Conditions, newCondition(
+			v1alpha1.ConditionSelectorValid, false, reasonInvalidSelector,
+			fmt.Sprintf("Invalid selector: %s", err),
+		))
+		return o.updateMonitorStatus(ctx, obj, status)
+	}
+	status.Conditions = setCondition(status.Conditions, newCondition(
+		v1alpha1.ConditionSelectorValid, true, reasonValidSelector, "",
+	))
+
+	if err := o.garbageCollectMonitors(ctx, obj); err != nil {
 		return fmt.Errorf("Error doing garbage collection for %s:%s: %s", obj.Namespace, obj.Name, err)
 	}
 
-	ingLabels, err := metav1.LabelSelectorAsSelector(obj.Spec.Selector)
-	if err != nil {
-		return fmt.Errorf("Could not create label selector for %s:%s: %s", obj.Namespace, obj.Name, err)
+	// The Provider and MonitorTemplate might not exist (yet). When they get
+	// added, the Monitor will be enqueued again through the indexers, so we
+	// mark the Monitor as unresolved instead of retrying.
+	prov, err := o.provLister.Providers(obj.Namespace).Get(obj.Spec.Provider.Name)
+	if kerrors.IsNotFound(err) {
+		status.Conditions = setCondition(status.Conditions, newCondition(
+			v1alpha1.ConditionReferencesResolved, false, reasonProviderNotFound,
+			fmt.Sprintf("Provider %s does not exist", obj.Spec.Provider.Name),
+		))
+		return o.updateMonitorStatus(ctx, obj, status)
+	} else if err != nil {
+		return fmt.Errorf("Could not get Provider %s:%s: %s", obj.Namespace, obj.Spec.Provider.Name, err)
 	}
 
-	ingressList, err := o.ingLister.Ingresses(obj.Namespace).List(ingLabels)
-	if err != nil {
-		return fmt.Errorf("Could not list Ingresses: %s", err)
+	tmpl, err := o.mtLister.MonitorTemplates(obj.Namespace).Get(obj.Spec.Template.Name)
+	if kerrors.IsNotFound(err) {
+		status.Conditions = setCondition(status.Conditions, newCondition(
+			v1alpha1.ConditionReferencesResolved, false, reasonTemplateNotFound,
+			fmt.Sprintf("MonitorTemplate %s does not exist", obj.Spec.Template.Name),
+		))
+		return o.updateMonitorStatus(ctx, obj, status)
+	} else if err != nil {
+		return fmt.Errorf("Could not get MonitorTemplate %s: %s", obj.Spec.Template.Name, err)
 	}
 
-	if len(ingressList) == 0 {
-		logrus.Infof("No ingresses selected for %s:%s", obj.Namespace, obj.Name)
-		return nil
+	status.Conditions = setCondition(status.Conditions, newCondition(
+		v1alpha1.ConditionReferencesResolved, true, reasonResolved, "",
+	))
+
+	// The Monitor is synced again when one of its maintenance windows starts
+	// or ends, so its IngressMonitors are paused and resumed in time.
+	windows, next := evaluateMaintenanceWindows(obj, status, time.Now())
+	if !next.IsZero() {
+		o.monitorQueue.AddAfter(key, time.Until(next))
 	}
+	policy := o.evaluateRolloutPolicy(obj, status)
 
-	prov, err := o.provLister.Providers(obj.Namespace).Get(obj.Spec.Provider.Name)
+	sel, err := o.selectedTargets(obj)
 	if err != nil {
-		return fmt.Errorf("Could not get Provider %s:%s: %s", obj.Namespace, obj.Spec.Provider.Name, err)
+		return err
 	}
 
-	tmpl, err := o.mtLister.MonitorTemplates(obj.Namespace).Get(obj.Spec.Template.Name)
-	if err != nil {
-		return fmt.Errorf("Could not get MonitorTemplate %s: %s", obj.Spec.Template.Name, err)
+	if len(sel.targets) == 0 {
+		logrus.Infof("No Ingresses, Services or HTTPRoutes selected for %s:%s", obj.Namespace, obj.Name)
 	}
 
-	// reconcile the newly selected Ingresses. We'll create new IngressMonitors
-	// for each Ingress and it's subsequent rules. If it already exists, we
+	var hosts, ready, paused int32
+	var imNames []string
+	annotationErrs := []string{}
+	var invalidAnnotations []invalidAnnotation
+	reported := map[string]bool{}
+	rollouts := map[string]bool{}
+
+	// reconcile the newly selected targets. We'll create new IngressMonitors
+	// for each Ingress rule, Service address and HTTPRoute hostname. If it already exists, we
 	// update it.
-	for _, ing := range ingressList {
-		for _, rule := range ing.Spec.Rules {
-			name := fmt.Sprintf("%s-%s", ing.Name, shortHash(rule.Host, 16))
-
-			// we can only assign one reference that controls the object, ensure
-			// that it's the Ingress so that we can still perform garbage
-			// collection.
-			monitorReference := *metav1.NewControllerRef(
-				obj,
-				v1alpha1.SchemeGroupVersion.WithKind("Monitor"),
-			)
-			monitorReference.Controller = nil
+	for _, t := range sel.targets {
+		monitorReference := *metav1.NewControllerRef(
+			obj,
+			v1alpha1.SchemeGroupVersion.WithKind("Monitor"),
+		)
+		monitorReference.Controller = nil
+
+		templateSpec := *tmpl.Spec.DeepCopy()
+		provSpec := *prov.Spec.DeepCopy()
+
+		// Annotations on the owner of the target override the settings of
+		// the MonitorTemplate. Invalid values are ignored and reported once
+		// for every owner.
+		var ownerErrs []error
+		errs := applyAnnotations(t.owner, &templateSpec, &provSpec)
+		if ownerKey := t.ownerRef.Kind + "/" + t.ownerRef.Name; !reported[ownerKey] {
+			reported[ownerKey] = true
+			ownerErrs = errs
+			for _, err := range errs {
+				annotationErrs = append(annotationErrs, fmt.Sprintf("%s %s: %s", t.ownerRef.Kind, t.ownerRef.Name, err))
+			}
+		}
 
-			templateSpec := tmpl.Spec
-			tplName, err := templatedName(ing, templateSpec)
+		// Checks of targets served by a workload which is being rolled out
+		// are paused or need more confirmations. They're restored as soon as
+		// the rollout is done, as the spec is built again on every sync.
+		var rollout string
+		if policy != nil {
+			workloads, err := o.rollingOutWorkloads(obj.Namespace, t.services)
 			if err != nil {
-				return fmt.Errorf("Could not get templated name: %s", err)
+				return err
 			}
-			templateSpec.Name = tplName
 
-			healthPath := "/_healthz"
-
-			scheme := "http://"
-		TLSLoop:
-			for _, tlsList := range ing.Spec.TLS {
-				for _, host := range tlsList.Hosts {
-					if host == rule.Host {
-						scheme = "https://"
-						break TLSLoop
-					}
-				}
+			for _, w := range workloads {
+				rollouts[w] = true
 			}
+			rollout = applyRolloutPolicy(policy, workloads, &templateSpec)
+		}
 
-			if templateSpec.HTTP.Endpoint != nil {
-				healthPath = *templateSpec.HTTP.Endpoint
+		tplName, err := templatedName(t, templateSpec)
+		if err != nil {
+			return fmt.Errorf("Could not get templated name: %s", err)
+		}
+		templateSpec.Name = tplName
+
+		// TCP, certificate and DNS checks default to the host of the target,
+		// all other checks are HTTP checks of the health endpoint.
+		switch templateSpec.Type {
+		case "TCP":
+			if templateSpec.TCP == nil {
+				templateSpec.TCP = &v1alpha1.TCPTemplate{}
+			}
+			tcp := templateSpec.TCP
+			if tcp.Host == "" {
+				tcp.Host = t.host
+			}
+			if tcp.Port == 0 {
+				tcp.Port = t.port()
+			}
+		case "Certificate":
+			if templateSpec.Certificate == nil {
+				templateSpec.Certificate = &v1alpha1.CertificateTemplate{}
+			}
+			cert := templateSpec.Certificate
+			if cert.Host == "" {
+				cert.Host = t.host
+			}
+			if cert.SecretName == "" {
+				cert.SecretName = t.tlsSecret
+			}
+		case "DNS":
+			if templateSpec.DNS == nil {
+				templateSpec.DNS = &v1alpha1.DNSTemplate{}
 			}
-			monitorURL := fmt.Sprintf("%s%s%s", scheme, rule.Host, healthPath)
-			templateSpec.HTTP.URL = monitorURL
-
-			im := &v1alpha1.IngressMonitor{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      name,
-					Namespace: ing.Namespace,
-					// Add OwnerReferences to the IngressMonitor so we can
-					// automatically Garbage Collect when either a Monitor is
-					// removed or when the Ingress is removed. This way we don't
-					// have to set this up ourselves.
-					OwnerReferences: []metav1.OwnerReference{
-						*metav1.NewControllerRef(
-							ing,
-							extensions.SchemeGroupVersion.WithKind("Ingress"),
-						),
-						monitorReference,
-					},
-					// Set some labels so it's easier to filter later on
-					Labels: map[string]string{
-						monitorLabel:     obj.Name,
-						ingressLabel:     ing.Name,
-						ingressHostLabel: rule.Host,
-					},
+			dns := templateSpec.DNS
+			if dns.Host == "" {
+				dns.Host = t.host
+			}
+			if len(dns.ExpectedRecords) == 0 {
+				dns.ExpectedRecords = t.records
+			}
+		default:
+			healthPath := "/_healthz"
+			if http := httpTemplate(&templateSpec); http.Endpoint != nil {
+				healthPath = *http.Endpoint
+			}
+			templateSpec.HTTP.URL = t.url(healthPath)
+		}
+
+		// Set some labels so it's easier to filter later on
+		imLabels := map[string]string{monitorLabel: obj.Name}
+		for k, v := range t.labels {
+			imLabels[k] = v
+		}
+
+		im := &v1alpha1.IngressMonitor{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      t.name,
+				Namespace: obj.Namespace,
+				// Add OwnerReferences to the IngressMonitor so we can
+				// automatically Garbage Collect when either a Monitor is
+				// removed or when the owner of the target is removed. This
+				// way we don't have to set this up ourselves.
+				OwnerReferences: []metav1.OwnerReference{
+					t.ownerRef,
+					monitorReference,
 				},
-				Spec: v1alpha1.IngressMonitorSpec{
-					Provider: v1alpha1.NamespacedProvider{
-						Namespace:    obj.Namespace,
-						ProviderSpec: prov.Spec,
-					},
-					Template: templateSpec,
+				Finalizers: []string{providerFinalizer},
+				Labels:     imLabels,
+			},
+			Spec: v1alpha1.IngressMonitorSpec{
+				Provider: v1alpha1.NamespacedProvider{
+					Namespace:    obj.Namespace,
+					ProviderSpec: provSpec,
 				},
-			}
+				Template:          templateSpec,
+				MaintenanceWindow: activeWindow(windows, t.owner.GetLabels()),
+				Paused:            hasPausedAnnotation(obj) || hasPausedAnnotation(t.owner),
+				Rollout:           rollout,
+			},
+		}
 
-			gIM, err := o.imClient.IngressMonitors(im.Namespace).
-				Get(im.Name, metav1.GetOptions{})
-			if kerrors.IsNotFound(err) {
-				_, err = o.imClient.IngressMonitors(im.Namespace).Create(im)
-			} else if err == nil {
-				im.ObjectMeta = gIM.ObjectMeta
-				im.TypeMeta = gIM.TypeMeta
-				im.Status = gIM.Status
-				im.Status.IngressName = ing.Name
-
-				_, err = o.imClient.IngressMonitors(im.Namespace).Update(im)
-			}
+		gIM, err := o.imClient.IngressMonitors(im.Namespace).
+			Get(ctx, im.Name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			im, err = o.imClient.IngressMonitors(im.Namespace).Create(ctx, im, metav1.CreateOptions{})
+		} else if err == nil {
+			im.ObjectMeta = gIM.ObjectMeta
+			im.TypeMeta = gIM.TypeMeta
+			im.Status = gIM.Status
+			im.OwnerReferences = migrateOwnerReferences(gIM.OwnerReferences, t.ownerRef)
+
+			im, err = o.imClient.IngressMonitors(im.Namespace).Update(ctx, im, metav1.UpdateOptions{})
+		}
 
-			if err != nil {
-				return fmt.Errorf("Could not ensure IngressMonitor: %s", err)
+		if err != nil {
+			return fmt.Errorf("Could not ensure IngressMonitor: %s", err)
+		}
+
+		for _, err := range ownerErrs {
+			invalidAnnotations = append(invalidAnnotations, invalidAnnotation{im: im, err: err})
+		}
+
+		hosts++
+		imNames = append(imNames, im.Name)
+		if isReady(im.Status.Conditions) {
+			ready++
+		}
+		if isPaused(im.Status.Conditions) {
+			paused++
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"ingress_monitor_namespace": im.Namespace,
+			"ingress_monitor_name":      im.Name,
+		}).Debug("successfully synced IngressMonitor")
+	}
+
+	if len(annotationErrs) > 0 {
+		// The Events are only recorded when the invalid annotations change,
+		// not on every resync.
+		cond := newCondition(
+			v1alpha1.ConditionAnnotationsValid, false, reasonInvalidAnnotations,
+			strings.Join(annotationErrs, "; "),
+		)
+		if prev := getCondition(status.Conditions, cond.Type); prev == nil || prev.Message != cond.Message {
+			for _, ae := range invalidAnnotations {
+				o.recordEvent(ae.im, v1.EventTypeWarning, eventReasonInvalidAnnotation, "Ignoring annotation: %s", ae.err)
 			}
+		}
+		status.Conditions = setCondition(status.Conditions, cond)
+	} else {
+		status.Conditions = setCondition(status.Conditions, newCondition(
+			v1alpha1.ConditionAnnotationsValid, true, reasonValidAnnotations, "",
+		))
+	}
 
-			logrus.WithFields(logrus.Fields{
-				"ingress_monitor_namespace": im.Namespace,
-				"ingress_monitor_name":      im.Name,
-			}).Debug("successfully synced IngressMonitor")
+	sort.Strings(imNames)
+	status.Ingresses = sel.ingresses
+	status.Services = sel.services
+	status.HTTPRoutes = sel.httpRoutes
+	status.Hosts = hosts
+	status.IngressMonitors = imNames
+	status.ReadyIngressMonitors = ready
+	status.PausedIngressMonitors = paused
+	for w := range rollouts {
+		status.Rollouts = append(status.Rollouts, w)
+	}
+	sort.Strings(status.Rollouts)
+
+	return o.updateMonitorStatus(ctx, obj, status)
+}
+
+// selectedIngresses lists the Ingresses which are selected by the Monitor
+// through the given label selector and its Ingress class.
+func (o *Operator) selectedIngresses(obj *v1alpha1.Monitor, sel labels.Selector) ([]*networkingv1.Ingress, error) {
+	ingressList, err := o.ingLister.Ingresses(obj.Namespace).List(sel)
+	if err != nil {
+		return nil, fmt.Errorf("Could not list Ingresses: %s", err)
+	}
+
+	var selected []*networkingv1.Ingress
+	for _, ing := range ingressList {
+		if monitorSelectsClass(obj, ing) {
+			selected = append(selected, ing)
 		}
 	}
 
+	return selected, nil
+}
+
+// updateMonitorStatus derives the Ready condition from the given status and
+// writes it to the API if anything has changed compared to the Monitor.
+func (o *Operator) updateMonitorStatus(ctx context.Context, obj *v1alpha1.Monitor, status *v1alpha1.MonitorStatus) error {
+	status.Conditions = setCondition(status.Conditions, monitorReadyCondition(status))
+
+	if reflect.DeepEqual(&obj.Status, status) {
+		return nil
+	}
+
+	mon := obj.DeepCopy()
+	mon.Status = *status
+	if _, err := o.imClient.Monitors(mon.Namespace).UpdateStatus(ctx, mon, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("Could not update status for Monitor %s:%s: %s", mon.Namespace, mon.Name, err)
+	}
+
 	return nil
 }
 
+// monitorReadyCondition determines the Ready condition of a Monitor. A Monitor
+// is Ready when its selector is valid, its references can be resolved and all
+// the IngressMonitors it manages are Ready.
+func monitorReadyCondition(status *v1alpha1.MonitorStatus) v1alpha1.Condition {
+	for _, tp := range []v1alpha1.ConditionType{
+		v1alpha1.ConditionSelectorValid,
+		v1alpha1.ConditionReferencesResolved,
+	} {
+		if cond := getCondition(status.Conditions, tp); cond != nil && cond.Status != v1.ConditionTrue {
+			return newCondition(v1alpha1.ConditionReady, false, cond.Reason, cond.Message)
+		}
+	}
+
+	if status.ReadyIngressMonitors < int32(len(status.IngressMonitors)) {
+		return newCondition(v1alpha1.ConditionReady, false, reasonIngressMonitorsNotReady, fmt.Sprintf(
+			"%d of %d IngressMonitors are ready", status.ReadyIngressMonitors, len(status.IngressMonitors),
+		))
+	}
+
+	return newCondition(v1alpha1.ConditionReady, true, reasonSynced, "")
+}
+
+func hasString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeString(list []string, s string) []string {
+	var result []string
+	for _, item := range list {
+		if item != s {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
 func listOptions(lbls map[string]string) metav1.ListOptions {
 	return metav1.ListOptions{
 		LabelSelector: labels.FormatLabels(lbls),
@@ -627,18 +1555,29 @@ func shortHash(data string, len int) string {
 	return strings.ToLower(encoder.EncodeToString(b2b.Sum(nil)))
 }
 
-func templatedName(ing *v1beta1.Ingress, sp v1alpha1.MonitorTemplateSpec) (string, error) {
+// templatedName executes the name template of the MonitorTemplate for the
+// given target. IngressName and IngressNamespace are kept for templates which
+// were written before Services could be monitored.
+func templatedName(t target, sp v1alpha1.MonitorTemplateSpec) (string, error) {
 	tpl, err := template.New("im-name").Parse(sp.Name)
 	if err != nil {
 		return "", err
 	}
 
 	data := struct {
+		Name             string
+		Namespace        string
+		Host             string
+		Path             string
 		IngressName      string
 		IngressNamespace string
 	}{
-		IngressName:      ing.Name,
-		IngressNamespace: ing.Namespace,
+		Name:             t.owner.GetName(),
+		Namespace:        t.owner.GetNamespace(),
+		Host:             t.host,
+		Path:             t.path,
+		IngressName:      t.owner.GetName(),
+		IngressNamespace: t.owner.GetNamespace(),
 	}
 
 	buf := bytes.NewBufferString("")
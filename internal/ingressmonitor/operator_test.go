@@ -1,8 +1,11 @@
 package ingressmonitor
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"testing"
 	"time"
 
@@ -13,12 +16,17 @@ import (
 	imfake "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/fake"
 	"github.com/prometheus/client_golang/prometheus"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 )
 
@@ -55,29 +63,97 @@ func TestOperator_RunShutdown(t *testing.T) {
 }
 
 func TestOperator_DeleteIngressMonitor(t *testing.T) {
-	t.Run("delete the monitor with the provider", func(t *testing.T) {
+	var op *operatorWrapper
+	var prov *fake.SimpleProvider
+
+	setup := func() {
+		op = newOperator(t)
+		prov = new(fake.SimpleProvider)
+		op.op.providerFactory.Register("simple", fake.FactoryFunc(prov))
+	}
+
+	deletedIngressMonitor := func() *v1alpha1.IngressMonitor {
+		now := metav1.Now()
+
 		im := newIngressMonitor()
 		im.Status.ID = "12345"
-		op := newOperator(t,
-			withIngressMonitors(im),
-			withIngresses(newIngress()),
-			withProviders(newProvider()),
-			withTemplates(newTemplate()),
-		)
+		im.DeletionTimestamp = &now
+		im.Finalizers = []string{providerFinalizer}
+		return im
+	}
 
-		prov := new(fake.SimpleProvider)
-		op.op.providerFactory.Register("simple", fake.FactoryFunc(prov))
+	t.Run("delete the monitor with the provider", func(t *testing.T) {
+		setup()
 
-		prov.DeleteFunc = func(id string) error {
+		prov.DeleteFunc = func(_ context.Context, id string) error {
 			strEquals(t, "12345", id, "deleting the IngressMonitor")
 			return nil
 		}
 
-		op.op.OnDelete(im)
+		im := deletedIngressMonitor()
+		errEquals(t, nil, op.handleIngressMonitor(t, im), "deleting an ingress monitor")
 
 		if prov.DeleteCount != 1 {
 			t.Errorf("Expected the delete action to be called")
 		}
+		eventsEqual(t, op, "Normal Deleted Deleted monitor 12345 from provider simple")
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		if len(im.Finalizers) != 0 {
+			t.Errorf("Expected the finalizer to be removed, got %v", im.Finalizers)
+		}
+	})
+
+	t.Run("with a monitor which doesn't exist with the provider", func(t *testing.T) {
+		setup()
+
+		prov.DeleteFunc = func(_ context.Context, id string) error {
+			return provider.ErrNotFound
+		}
+
+		im := deletedIngressMonitor()
+		errEquals(t, nil, op.handleIngressMonitor(t, im), "deleting an ingress monitor")
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		if len(im.Finalizers) != 0 {
+			t.Errorf("Expected the finalizer to be removed, got %v", im.Finalizers)
+		}
+	})
+
+	t.Run("with a provider error", func(t *testing.T) {
+		setup()
+
+		prov.DeleteFunc = func(_ context.Context, id string) error {
+			return errors.New("provider unavailable")
+		}
+
+		im := deletedIngressMonitor()
+		expErr := errors.New("Could not delete monitor '12345' with provider: provider unavailable")
+		errEquals(t, expErr, op.handleIngressMonitor(t, im), "deleting an ingress monitor")
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		if len(im.Finalizers) != 1 {
+			t.Errorf("Expected the finalizer to be kept, got %v", im.Finalizers)
+		}
+		eventsEqual(t, op, "Warning ProviderError Could not delete monitor '12345' with provider: provider unavailable")
+	})
+
+	t.Run("without a monitor ID", func(t *testing.T) {
+		setup()
+
+		im := deletedIngressMonitor()
+		im.Status.ID = ""
+		errEquals(t, nil, op.handleIngressMonitor(t, im), "deleting an ingress monitor")
+
+		if prov.DeleteCount != 0 {
+			t.Errorf("Expected the delete action not to be called")
+		}
 	})
 }
 
@@ -92,7 +168,7 @@ func TestOperator_DeleteMonitor(t *testing.T) {
 		mon := newMonitor()
 		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
 
-		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(metav1.ListOptions{})
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
 		errEquals(t, nil, err, "listing the IngressMonitors")
 
 		if len(imList.Items) != 1 {
@@ -101,7 +177,7 @@ func TestOperator_DeleteMonitor(t *testing.T) {
 
 		op.op.OnDelete(mon)
 
-		imList, err = op.op.imClient.IngressMonitors(mon.Namespace).List(metav1.ListOptions{})
+		imList, err = op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
 		errEquals(t, nil, err, "listing the IngressMonitors")
 
 		if len(imList.Items) != 0 {
@@ -117,6 +193,14 @@ func TestOperator_SyncIngressMonitor(t *testing.T) {
 		im := newIngressMonitor()
 		expError := fmt.Errorf("Error fetching provider 'simple': the specified provider can't be found")
 		errEquals(t, expError, op.handleIngressMonitor(t, im))
+		eventsEqual(t, op, "Warning ProviderError "+expError.Error())
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		strEquals(t, expError.Error(), im.Status.LastError, "last error")
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionCredentialsResolved, v1.ConditionFalse, reasonProviderUnavailable)
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionFalse, reasonProviderUnavailable)
 	})
 
 	t.Run("with enqueued item already deleted", func(t *testing.T) {
@@ -125,7 +209,7 @@ func TestOperator_SyncIngressMonitor(t *testing.T) {
 		im := newIngressMonitor()
 		// call the operator handleIngressMonitor function directly, bypassing
 		// adding data to the cache
-		errEquals(t, nil, op.op.handleIngressMonitor(getKey(t, im)))
+		errEquals(t, nil, op.op.handleIngressMonitor(context.TODO(), getKey(t, im)))
 	})
 
 	t.Run("with provider configured", func(t *testing.T) {
@@ -142,29 +226,75 @@ func TestOperator_SyncIngressMonitor(t *testing.T) {
 			t.Run("without an error", func(t *testing.T) {
 				setup()
 
-				prov.CreateFunc = func(tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+				prov.CreateFunc = func(_ context.Context, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
 					return "12345", nil
 				}
 
 				im := newIngressMonitor()
+				im.Generation = 2
 				errEquals(t, nil, op.handleIngressMonitor(t, im), "adding an ingress monitor")
 
-				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(im.Name, metav1.GetOptions{})
+				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
 				errEquals(t, nil, err, "getting updated IngressMonitor")
 
 				strEquals(t, "12345", im.Status.ID, "status should be the same")
+				strEquals(t, "", im.Status.LastError, "last error")
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionCredentialsResolved, v1.ConditionTrue, reasonResolved)
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionProviderSynced, v1.ConditionTrue, reasonSynced)
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionTrue, reasonSynced)
+
+				if im.Status.ObservedGeneration != 2 {
+					t.Errorf("Expected observed generation to be 2, got %d", im.Status.ObservedGeneration)
+				}
+
+				if im.Status.LastSyncTime == nil {
+					t.Errorf("Expected the last sync time to be set")
+				}
+
+				if !reflect.DeepEqual([]string{providerFinalizer}, im.Finalizers) {
+					t.Errorf("Expected the finalizer to be added, got %v", im.Finalizers)
+				}
+
+				eventsEqual(t, op, "Normal Created Created monitor 12345 with provider simple")
 			})
 
-			t.Run("without an error", func(t *testing.T) {
+			t.Run("with an error", func(t *testing.T) {
 				setup()
 
 				expErr := errors.New("can't create monitor")
-				prov.CreateFunc = func(tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+				prov.CreateFunc = func(_ context.Context, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
 					return "12345", expErr
 				}
 
 				im := newIngressMonitor()
 				errEquals(t, expErr, op.handleIngressMonitor(t, im), "adding an ingress monitor")
+
+				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+				errEquals(t, nil, err, "getting updated IngressMonitor")
+
+				strEquals(t, expErr.Error(), im.Status.LastError, "last error")
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionCredentialsResolved, v1.ConditionTrue, reasonResolved)
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionProviderSynced, v1.ConditionFalse, reasonSyncFailed)
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionFalse, reasonSyncFailed)
+
+				eventsEqual(t, op, "Warning ProviderError Could not sync monitor with provider simple: can't create monitor")
+			})
+
+			t.Run("with a provider timeout", func(t *testing.T) {
+				setup()
+
+				prov.CreateFunc = func(ctx context.Context, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+					deadline, ok := ctx.Deadline()
+					if !ok || time.Until(deadline) > 5*time.Second {
+						t.Errorf("Expected the call to time out within 5s, got %s", time.Until(deadline))
+					}
+
+					return "12345", nil
+				}
+
+				im := newIngressMonitor()
+				im.Spec.Provider.Timeout = ptrString("5s")
+				errEquals(t, nil, op.handleIngressMonitor(t, im), "adding an ingress monitor")
 			})
 		})
 
@@ -172,7 +302,7 @@ func TestOperator_SyncIngressMonitor(t *testing.T) {
 			t.Run("without an error", func(t *testing.T) {
 				setup()
 
-				prov.UpdateFunc = func(id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+				prov.UpdateFunc = func(_ context.Context, id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
 					strEquals(t, "12345", id, "id to update")
 
 					return "123456", nil
@@ -182,17 +312,63 @@ func TestOperator_SyncIngressMonitor(t *testing.T) {
 				im.Status.ID = "12345"
 				errEquals(t, nil, op.handleIngressMonitor(t, im), "updating an ingress monitor")
 
-				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(im.Name, metav1.GetOptions{})
+				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
 				errEquals(t, nil, err, "getting updated IngressMonitor")
 
 				strEquals(t, "123456", im.Status.ID, "status should be the same")
+
+				eventsEqual(t, op, "Normal Recreated Monitor 12345 was not found with provider simple, recreated it as 123456")
 			})
 
-			t.Run("without an error", func(t *testing.T) {
+			t.Run("with a changed spec", func(t *testing.T) {
+				setup()
+
+				prov.UpdateFunc = func(_ context.Context, id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+					return id, nil
+				}
+
+				im := newIngressMonitor()
+				im.Generation = 2
+				im.Status.ID = "12345"
+				im.Status.ObservedGeneration = 1
+				errEquals(t, nil, op.handleIngressMonitor(t, im), "updating an ingress monitor")
+				eventsEqual(t, op, "Normal Updated Updated monitor 12345 with provider simple")
+
+				t.Run("resyncing without changes", func(t *testing.T) {
+					im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+					errEquals(t, nil, err, "getting updated IngressMonitor")
+
+					op.op.imInformer.GetIndexer().Update(im)
+					op.imClient.ClearActions()
+					errEquals(t, nil, op.op.handleIngressMonitor(context.TODO(), getKey(t, im)), "resyncing an ingress monitor")
+					eventsEqual(t, op)
+
+					if actions := op.imClient.Actions(); len(actions) != 0 {
+						t.Errorf("Expected the status not to be updated, got %v", actions)
+					}
+				})
+
+				t.Run("resyncing after the sync interval", func(t *testing.T) {
+					im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+					errEquals(t, nil, err, "getting updated IngressMonitor")
+
+					lastSync := metav1.NewTime(im.Status.LastSyncTime.Add(-lastSyncInterval))
+					im.Status.LastSyncTime = &lastSync
+					op.op.imInformer.GetIndexer().Update(im)
+					op.imClient.ClearActions()
+					errEquals(t, nil, op.op.handleIngressMonitor(context.TODO(), getKey(t, im)), "resyncing an ingress monitor")
+
+					if actions := op.imClient.Actions(); len(actions) != 1 || actions[0].GetSubresource() != "status" {
+						t.Errorf("Expected the status to be updated, got %v", actions)
+					}
+				})
+			})
+
+			t.Run("with an error", func(t *testing.T) {
 				setup()
 
 				expErr := errors.New("can't create monitor")
-				prov.UpdateFunc = func(id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+				prov.UpdateFunc = func(_ context.Context, id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
 					strEquals(t, "12345", id, "id to update")
 
 					return id, expErr
@@ -202,24 +378,196 @@ func TestOperator_SyncIngressMonitor(t *testing.T) {
 				im.Status.ID = "12345"
 				errEquals(t, expErr, op.handleIngressMonitor(t, im), "updating an ingress monitor")
 			})
+
+			t.Run("with a removed monitor", func(t *testing.T) {
+				setup()
+
+				prov.UpdateFunc = func(_ context.Context, id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+					return id, provider.ErrNotFound
+				}
+				prov.CreateFunc = func(_ context.Context, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+					return "123456", nil
+				}
+
+				im := newIngressMonitor()
+				im.Status.ID = "12345"
+				errEquals(t, nil, op.handleIngressMonitor(t, im), "updating an ingress monitor")
+
+				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+				errEquals(t, nil, err, "getting updated IngressMonitor")
+
+				strEquals(t, "123456", im.Status.ID, "status ID")
+				eventsEqual(t, op, "Normal Recreated Monitor 12345 was not found with provider simple, recreated it as 123456")
+			})
+
+			t.Run("without changes with the provider", func(t *testing.T) {
+				setup()
+
+				prov.UpdateFunc = func(_ context.Context, id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+					return "", provider.ErrNoChange
+				}
+
+				im := newIngressMonitor()
+				im.Status.ID = "12345"
+				errEquals(t, nil, op.handleIngressMonitor(t, im), "updating an ingress monitor")
+
+				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+				errEquals(t, nil, err, "getting updated IngressMonitor")
+
+				strEquals(t, "12345", im.Status.ID, "status ID")
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionTrue, reasonSynced)
+			})
+
+			t.Run("with rejected credentials", func(t *testing.T) {
+				setup()
+
+				prov.UpdateFunc = func(_ context.Context, id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+					return id, provider.ErrUnauthorized
+				}
+
+				im := newIngressMonitor()
+				im.Status.ID = "12345"
+				errEquals(t, provider.ErrUnauthorized, op.handleIngressMonitor(t, im), "updating an ingress monitor")
+
+				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+				errEquals(t, nil, err, "getting updated IngressMonitor")
+
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionCredentialsResolved, v1.ConditionFalse, reasonUnauthorized)
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionFalse, reasonUnauthorized)
+			})
+
+			t.Run("with an invalid spec", func(t *testing.T) {
+				setup()
+
+				expErr := provider.InvalidSpec(errors.New("invalid timeout"))
+				prov.UpdateFunc = func(_ context.Context, id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+					return id, expErr
+				}
+
+				im := newIngressMonitor()
+				im.Status.ID = "12345"
+				errEquals(t, expErr, op.handleIngressMonitor(t, im), "updating an ingress monitor")
+
+				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+				errEquals(t, nil, err, "getting updated IngressMonitor")
+
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionProviderSynced, v1.ConditionFalse, reasonInvalidSpec)
+			})
+
+			t.Run("with an unsupported check type", func(t *testing.T) {
+				setup()
+
+				expErr := &provider.UnsupportedTypeError{Provider: "Fake", Type: "TCP"}
+				prov.UpdateFunc = func(_ context.Context, id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+					return id, expErr
+				}
+
+				im := newIngressMonitor()
+				im.Status.ID = "12345"
+				errEquals(t, expErr, op.handleIngressMonitor(t, im), "updating an ingress monitor")
+
+				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+				errEquals(t, nil, err, "getting updated IngressMonitor")
+
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionProviderSynced, v1.ConditionFalse, reasonUnsupportedType)
+			})
+
+			t.Run("with unsupported assertions", func(t *testing.T) {
+				setup()
+
+				unsupported := &provider.UnsupportedAssertionsError{Provider: "Fake", Assertions: []string{`regex "^ok$"`}}
+				prov.UpdateFunc = func(_ context.Context, id string, tpl v1alpha1.MonitorTemplateSpec) (string, error) {
+					return id, unsupported
+				}
+
+				im := newIngressMonitor()
+				im.Status.ID = "12345"
+				im.Spec.Template.HTTP = &v1alpha1.HTTPTemplate{
+					Assertions: []v1alpha1.Assertion{{Regex: "^ok$"}},
+				}
+				errEquals(t, nil, op.handleIngressMonitor(t, im), "updating an ingress monitor")
+
+				im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+				errEquals(t, nil, err, "getting updated IngressMonitor")
+
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionProviderSynced, v1.ConditionTrue, reasonSynced)
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionAssertionsSupported, v1.ConditionFalse, reasonUnsupportedAssertions)
+				conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionTrue, reasonSynced)
+				eventsEqual(t, op, "Warning UnsupportedAssertions "+unsupported.Error())
+
+				// The same assertions aren't reported twice.
+				op.op.imInformer.GetIndexer().Update(im)
+				errEquals(t, nil, op.op.handleIngressMonitor(context.TODO(), getKey(t, im)))
+				eventsEqual(t, op)
+			})
 		})
 	})
 }
 
+func TestOperator_HandleNextItem(t *testing.T) {
+	tcs := []struct {
+		name     string
+		err      error
+		queued   int
+		requeues int
+	}{
+		{"without an error", nil, 0, 0},
+		{"with an error", errors.New("provider unavailable"), 0, 1},
+		{"with an invalid spec", provider.InvalidSpec(errors.New("invalid timeout")), 0, 0},
+		{"with an unsupported check type", &provider.UnsupportedTypeError{Provider: "Fake", Type: "TCP"}, 0, 0},
+		{"when rate limited", &provider.RateLimitError{}, 1, 0},
+		{"when rate limited for a while", &provider.RateLimitError{RetryAfter: time.Hour}, 0, 0},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			op := newOperator(t)
+			queue := workqueue.NewRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Hour, time.Hour))
+			defer queue.ShutDown()
+
+			queue.Add("testing/test-im")
+			op.op.handleNextItem(context.TODO(), "test", queue, func(context.Context, string) error {
+				return tc.err
+			})
+
+			if queue.Len() != tc.queued {
+				t.Errorf("Expected %d queued items, got %d", tc.queued, queue.Len())
+			}
+
+			if queue.NumRequeues("testing/test-im") != tc.requeues {
+				t.Errorf("Expected %d requeues, got %d", tc.requeues, queue.NumRequeues("testing/test-im"))
+			}
+		})
+	}
+}
+
 func TestOperator_SyncMonitor(t *testing.T) {
 	t.Run("without matching ingresses", func(t *testing.T) {
 		op := newOperator(t)
 
 		mon := newMonitor()
 		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		// The API drops the empty list, a non-nil one would make every
+		// resync rewrite the status.
+		if mon.Status.IngressMonitors != nil {
+			t.Errorf("Expected no IngressMonitors, got %#v", mon.Status.IngressMonitors)
+		}
 	})
 
 	t.Run("without existing provider", func(t *testing.T) {
 		op := newOperator(t, withIngresses(newIngress()))
 
 		mon := newMonitor()
-		expError := fmt.Errorf("Could not get Provider testing:test-provider: provider.ingressmonitor.sphc.io \"test-provider\" not found")
-		errEquals(t, expError, op.handleMonitor(t, mon))
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionReferencesResolved, v1.ConditionFalse, reasonProviderNotFound)
 	})
 
 	t.Run("without existing template", func(t *testing.T) {
@@ -229,8 +577,27 @@ func TestOperator_SyncMonitor(t *testing.T) {
 		)
 
 		mon := newMonitor()
-		expError := fmt.Errorf("Could not get MonitorTemplate test-template: monitortemplate.ingressmonitor.sphc.io \"test-template\" not found")
-		errEquals(t, expError, op.handleMonitor(t, mon))
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionReferencesResolved, v1.ConditionFalse, reasonTemplateNotFound)
+	})
+
+	t.Run("with existing provider and template", func(t *testing.T) {
+		op := newOperator(t,
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionReferencesResolved, v1.ConditionTrue, reasonResolved)
 	})
 
 	t.Run("with an ingress provider and template should create an IngressMonitor", func(t *testing.T) {
@@ -250,20 +617,581 @@ func TestOperator_SyncMonitor(t *testing.T) {
 		mon := newMonitor()
 		errEquals(t, nil, op.handleMonitor(t, mon), "creating a new monitor")
 
-		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(metav1.ListOptions{})
-		errEquals(t, nil, err, "listing the IngressMonitors")
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+
+		if len(imList.Items) != 1 {
+			t.Errorf("Expected 1 IngressMonitor to be created")
+		}
+
+		im := imList.Items[0]
+
+		expName := "test-go-ingress-testing"
+		strEquals(t, expName, im.Spec.Template.Name)
+
+		expURL := "https://api.example.com/test-healthz"
+		strEquals(t, expURL, im.Spec.Template.HTTP.URL)
+
+		mon, err = op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		if mon.Status.Ingresses != 1 || mon.Status.Hosts != 1 || mon.Status.ReadyIngressMonitors != 0 {
+			t.Errorf("Expected 1 Ingress, 1 host and 0 ready IngressMonitors, got %#v", mon.Status)
+		}
+
+		if !reflect.DeepEqual([]string{im.Name}, mon.Status.IngressMonitors) {
+			t.Errorf("Expected IngressMonitors to be %v, got %v", []string{im.Name}, mon.Status.IngressMonitors)
+		}
+
+		conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionSelectorValid, v1.ConditionTrue, reasonValidSelector)
+		conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionAnnotationsValid, v1.ConditionTrue, reasonValidAnnotations)
+		conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionFalse, reasonIngressMonitorsNotReady)
+
+		t.Run("with ready IngressMonitors", func(t *testing.T) {
+			im.Status.Conditions = setCondition(im.Status.Conditions, newCondition(v1alpha1.ConditionReady, true, reasonSynced, ""))
+			_, err := op.op.imClient.IngressMonitors(im.Namespace).UpdateStatus(context.TODO(), &im, metav1.UpdateOptions{})
+			errEquals(t, nil, err, "updating the IngressMonitor status")
+
+			errEquals(t, nil, op.handleMonitor(t, newMonitor()), "resyncing the monitor")
+
+			mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+			errEquals(t, nil, err, "getting updated Monitor")
+
+			if mon.Status.ReadyIngressMonitors != 1 {
+				t.Errorf("Expected 1 ready IngressMonitor, got %d", mon.Status.ReadyIngressMonitors)
+			}
+			conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionTrue, reasonSynced)
+		})
+	})
+
+	t.Run("with an invalid selector", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		mon.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{
+			{Key: "team", Operator: "Unknown"},
+		}
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionSelectorValid, v1.ConditionFalse, reasonInvalidSelector)
+		conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionFalse, reasonInvalidSelector)
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+
+		if len(imList.Items) != 0 {
+			t.Errorf("Expected no IngressMonitors to be created, got %d", len(imList.Items))
+		}
+	})
+
+	t.Run("with an ingress class", func(t *testing.T) {
+		nginx := newIngress()
+		nginx.Spec.IngressClassName = ptrString("nginx")
+
+		legacy := newIngress()
+		legacy.Name = "legacy-ingress"
+		legacy.UID = "legacy-ingress-uid"
+		legacy.Annotations = map[string]string{ingressClassAnnotation: "nginx"}
+		legacy.Spec.Rules = []networkingv1.IngressRule{{Host: "legacy.example.com"}}
+
+		traefik := newIngress()
+		traefik.Name = "traefik-ingress"
+		traefik.UID = "traefik-ingress-uid"
+		traefik.Spec.IngressClassName = ptrString("traefik")
+		traefik.Spec.Rules = []networkingv1.IngressRule{{Host: "traefik.example.com"}}
+
+		op := newOperator(t,
+			withIngresses(nginx, legacy, traefik),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		mon.Spec.IngressClassName = ptrString("nginx")
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		if mon.Status.Ingresses != 2 {
+			t.Errorf("Expected 2 Ingresses to be selected, got %d", mon.Status.Ingresses)
+		}
+	})
+
+	t.Run("migrating the Ingress owner reference", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		if len(imList.Items) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+		}
+
+		// Mimic an IngressMonitor which was created when the Operator used
+		// the extensions/v1beta1 API.
+		im := imList.Items[0]
+		im.OwnerReferences[0].APIVersion = "extensions/v1beta1"
+		_, err = op.op.imClient.IngressMonitors(im.Namespace).Update(context.TODO(), &im, metav1.UpdateOptions{})
+		errEquals(t, nil, err, "updating the IngressMonitor")
+
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		gIM, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		if len(gIM.OwnerReferences) != 2 {
+			t.Fatalf("Expected 2 owner references, got %v", gIM.OwnerReferences)
+		}
+		strEquals(t, "networking.k8s.io/v1", gIM.OwnerReferences[0].APIVersion, "Ingress owner reference")
+		strEquals(t, "go-ingress-uid", string(gIM.OwnerReferences[0].UID), "Ingress owner reference")
+	})
+
+	t.Run("with the legacy Ingress API", func(t *testing.T) {
+		op := newOperator(t,
+			withLegacyIngressAPI(),
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		if len(imList.Items) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+		}
+
+		strEquals(t, "extensions/v1beta1", imList.Items[0].OwnerReferences[0].APIVersion, "Ingress owner reference")
+	})
+
+	t.Run("per path", func(t *testing.T) {
+		ing := newIngress()
+		ing.Spec.Rules[0].HTTP = &networkingv1.HTTPIngressRuleValue{
+			Paths: []networkingv1.HTTPIngressPath{{Path: "/api"}, {Path: "/web"}},
+		}
+
+		op := newOperator(t,
+			withIngresses(ing),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		mon.Spec.PerPath = true
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		if len(imList.Items) != 2 {
+			t.Fatalf("Expected 2 IngressMonitors to be created, got %d", len(imList.Items))
+		}
+
+		var urls []string
+		for _, im := range imList.Items {
+			urls = append(urls, im.Spec.Template.HTTP.URL)
+			op.op.imInformer.GetIndexer().Add(im.DeepCopy())
+		}
+		sort.Strings(urls)
+
+		expURLs := []string{"https://api.example.com/api/test-healthz", "https://api.example.com/web/test-healthz"}
+		if !reflect.DeepEqual(expURLs, urls) {
+			t.Errorf("Expected URLs %v, got %v", expURLs, urls)
+		}
+
+		t.Run("once a path is removed", func(t *testing.T) {
+			ing := newIngress()
+			ing.Spec.Rules[0].HTTP = &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{{Path: "/api"}},
+			}
+			op.op.ingInformer.GetIndexer().Update(ing)
+
+			errEquals(t, nil, op.handleMonitor(t, mon))
+
+			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+			errEquals(t, nil, err, "listing the IngressMonitors")
+			if len(imList.Items) != 1 {
+				t.Fatalf("Expected 1 IngressMonitor to be left, got %d", len(imList.Items))
+			}
+
+			strEquals(t, "https://api.example.com/api/test-healthz", imList.Items[0].Spec.Template.HTTP.URL)
+		})
+	})
+
+	t.Run("with annotation overrides", func(t *testing.T) {
+		ing := newIngress()
+		ing.Annotations = map[string]string{
+			endpointAnnotation:      "/status",
+			shouldContainAnnotation: "OK",
+			timeoutAnnotation:       "10s",
+		}
+
+		tmpl := newTemplate()
+		op := newOperator(t,
+			withIngresses(ing),
+			withProviders(newProvider()),
+			withTemplates(tmpl),
+		)
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		if len(imList.Items) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+		}
+
+		spec := imList.Items[0].Spec.Template
+		strEquals(t, "https://api.example.com/status", spec.HTTP.URL, "URL")
+		strEquals(t, "OK", spec.HTTP.ShouldContain, "should contain")
+		strEquals(t, "10s", *spec.Timeout, "timeout")
+
+		// The overrides only apply to this Ingress, the MonitorTemplate
+		// itself is left untouched.
+		strEquals(t, "/test-healthz", *tmpl.Spec.HTTP.Endpoint, "template endpoint")
+		strEquals(t, "", tmpl.Spec.HTTP.URL, "template URL")
+	})
+
+	t.Run("with a TCP template", func(t *testing.T) {
+		tmpl := newTemplate()
+		tmpl.Spec.Type = "TCP"
+		tmpl.Spec.HTTP = nil
+
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withServices(newService()),
+			withProviders(newProvider()),
+			withTemplates(tmpl),
+		)
+
+		mon := newMonitor()
+		mon.Spec.Services = &v1alpha1.ServiceSelector{Selector: newMonitor().Spec.Selector}
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		if len(imList.Items) != 2 {
+			t.Fatalf("Expected 2 IngressMonitors to be created, got %d", len(imList.Items))
+		}
+
+		tcps := map[string]v1alpha1.TCPTemplate{}
+		for _, im := range imList.Items {
+			if im.Spec.Template.HTTP != nil || im.Spec.Template.TCP == nil {
+				t.Fatalf("Expected only a TCP template, got %#v", im.Spec.Template)
+			}
+			tcps[im.Spec.Template.TCP.Host] = *im.Spec.Template.TCP
+		}
+
+		// Ingresses are checked on the port of their scheme, Services on
+		// their own port.
+		exp := map[string]v1alpha1.TCPTemplate{
+			"api.example.com": {Host: "api.example.com", Port: 443},
+			"203.0.113.10":    {Host: "203.0.113.10", Port: 8080},
+		}
+		if !reflect.DeepEqual(exp, tcps) {
+			t.Errorf("Expected TCP templates %#v, got %#v", exp, tcps)
+		}
+	})
+
+	t.Run("with a certificate template", func(t *testing.T) {
+		tmpl := newTemplate()
+		tmpl.Spec.Type = "Certificate"
+		tmpl.Spec.HTTP = nil
+
+		ing := newIngress()
+		ing.Spec.TLS[0].SecretName = "api-tls"
+		ing.Spec.Rules = append(ing.Spec.Rules, networkingv1.IngressRule{Host: "www.example.com"})
+
+		op := newOperator(t,
+			withIngresses(ing),
+			withProviders(newProvider()),
+			withTemplates(tmpl),
+		)
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+
+		// Only hosts which are served over TLS have a certificate to check.
+		if len(imList.Items) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+		}
+
+		exp := &v1alpha1.CertificateTemplate{Host: "api.example.com", SecretName: "api-tls"}
+		if !reflect.DeepEqual(exp, imList.Items[0].Spec.Template.Certificate) {
+			t.Errorf("Expected certificate template %#v, got %#v", exp, imList.Items[0].Spec.Template.Certificate)
+		}
+	})
+
+	t.Run("with a DNS template", func(t *testing.T) {
+		tmpl := newTemplate()
+		tmpl.Spec.Type = "DNS"
+		tmpl.Spec.HTTP = nil
+
+		ing := newIngress()
+		ing.Status.LoadBalancer.Ingress = []v1.LoadBalancerIngress{{IP: "203.0.113.20"}, {Hostname: "lb.example.com"}}
+
+		// The load balancer of this Ingress hasn't been provisioned yet, so
+		// there's nothing to check.
+		pending := newIngress()
+		pending.Name = "pending-ingress"
+		pending.UID = "pending-ingress-uid"
+		pending.Spec.Rules[0].Host = "pending.example.com"
+
+		op := newOperator(t,
+			withIngresses(ing, pending),
+			withServices(newService()),
+			withProviders(newProvider()),
+			withTemplates(tmpl),
+		)
+
+		mon := newMonitor()
+		mon.Spec.Services = &v1alpha1.ServiceSelector{Selector: newMonitor().Spec.Selector}
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+
+		// The Service is only reachable through its IP, which doesn't need
+		// to resolve.
+		if len(imList.Items) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+		}
+
+		exp := &v1alpha1.DNSTemplate{Host: "api.example.com", ExpectedRecords: []string{"203.0.113.20", "lb.example.com"}}
+		if !reflect.DeepEqual(exp, imList.Items[0].Spec.Template.DNS) {
+			t.Errorf("Expected DNS template %#v, got %#v", exp, imList.Items[0].Spec.Template.DNS)
+		}
+	})
+
+	t.Run("with monitoring disabled", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(newIngress()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		if len(imList.Items) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+		}
+		op.op.imInformer.GetIndexer().Add(imList.Items[0].DeepCopy())
+
+		ing := newIngress()
+		ing.Annotations = map[string]string{disabledAnnotation: "true"}
+		op.op.ingInformer.GetIndexer().Update(ing)
+
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		imList, err = op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		if len(imList.Items) != 0 {
+			t.Fatalf("Expected the IngressMonitor to be removed, got %d", len(imList.Items))
+		}
+
+		mon, err = op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+		if mon.Status.Ingresses != 1 || mon.Status.Hosts != 0 {
+			t.Errorf("Expected 1 Ingress and 0 hosts, got %#v", mon.Status)
+		}
+	})
+
+	t.Run("with invalid annotations", func(t *testing.T) {
+		ing := newIngress()
+		ing.Annotations = map[string]string{timeoutAnnotation: "soon"}
+
+		op := newOperator(t,
+			withIngresses(ing),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		if len(imList.Items) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+		}
+
+		if imList.Items[0].Spec.Template.Timeout != nil {
+			t.Errorf("Expected the invalid timeout to be ignored, got %s", *imList.Items[0].Spec.Template.Timeout)
+		}
+
+		mon, err = op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+		conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionAnnotationsValid, v1.ConditionFalse, reasonInvalidAnnotations)
+
+		// The Event is recorded on the IngressMonitor, Monitor and Ingress.
+		event := `Warning InvalidAnnotation Ignoring annotation: invalid value "soon" for ingressmonitor.sphc.io/timeout: time: invalid duration "soon"`
+		eventsEqual(t, op, event, event, event)
+
+		t.Run("resyncing without changes", func(t *testing.T) {
+			op.op.mInformer.GetIndexer().Update(mon)
+			errEquals(t, nil, op.op.handleMonitor(context.TODO(), getKey(t, mon)), "resyncing the monitor")
+			eventsEqual(t, op)
+		})
+	})
+
+	t.Run("with a LoadBalancer Service", func(t *testing.T) {
+		op := newOperator(t,
+			withServices(newService()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		mon.Spec.Selector = nil
+		mon.Spec.Services = &v1alpha1.ServiceSelector{Selector: newMonitor().Spec.Selector}
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		if len(imList.Items) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created, got %d", len(imList.Items))
+		}
+
+		im := imList.Items[0]
+		strEquals(t, "http://203.0.113.10:8080/test-healthz", im.Spec.Template.HTTP.URL, "URL")
+		strEquals(t, "test-go-service-testing", im.Spec.Template.Name, "name")
+		strEquals(t, "go-service", im.Labels[serviceLabel], "service label")
+		strEquals(t, "v1", im.OwnerReferences[0].APIVersion, "Service owner reference")
+		strEquals(t, "Service", im.OwnerReferences[0].Kind, "Service owner reference")
+
+		mon, err = op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		if mon.Status.Ingresses != 0 || mon.Status.Services != 1 || mon.Status.Hosts != 1 {
+			t.Errorf("Expected 0 Ingresses, 1 Service and 1 host, got %#v", mon.Status)
+		}
+
+		t.Run("once the Service isn't a LoadBalancer anymore", func(t *testing.T) {
+			svc := newService()
+			svc.Spec.Type = v1.ServiceTypeClusterIP
+			op.op.svcInformer.GetIndexer().Update(svc)
+			op.op.imInformer.GetIndexer().Add(&im)
+
+			mon := newMonitor()
+			mon.Spec.Selector = nil
+			mon.Spec.Services = &v1alpha1.ServiceSelector{Selector: newMonitor().Spec.Selector}
+			errEquals(t, nil, op.handleMonitor(t, mon))
+
+			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+			errEquals(t, nil, err, "listing the IngressMonitors")
+			if len(imList.Items) != 0 {
+				t.Errorf("Expected the IngressMonitor to be garbage collected, got %d", len(imList.Items))
+			}
+		})
+	})
+
+	t.Run("with an HTTPRoute", func(t *testing.T) {
+		op := newOperator(t,
+			withHTTPRoutes(newHTTPRoute()),
+			withGateways(newGateway()),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newMonitor()
+		mon.Spec.Selector = nil
+		mon.Spec.HTTPRoutes = &v1alpha1.HTTPRouteSelector{Selector: newMonitor().Spec.Selector}
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		if len(imList.Items) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor to be created for the non-wildcard hostname, got %d", len(imList.Items))
+		}
+
+		im := imList.Items[0]
+		strEquals(t, "https://route.example.com/test-healthz", im.Spec.Template.HTTP.URL, "URL")
+		strEquals(t, "go-route", im.Labels[httpRouteLabel], "HTTPRoute label")
+		strEquals(t, "gateway.networking.k8s.io/v1", im.OwnerReferences[0].APIVersion, "HTTPRoute owner reference")
+		strEquals(t, "go-route-uid", string(im.OwnerReferences[0].UID), "HTTPRoute owner reference")
+
+		mon, err = op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		if mon.Status.HTTPRoutes != 1 || mon.Status.Hosts != 1 {
+			t.Errorf("Expected 1 HTTPRoute and 1 host, got %#v", mon.Status)
+		}
+
+		t.Run("once a hostname is removed", func(t *testing.T) {
+			route := newHTTPRoute()
+			unstructured.SetNestedStringSlice(route.Object, []string{"other.example.com"}, "spec", "hostnames")
+			op.op.routeInformer.GetIndexer().Update(route)
+			op.op.imInformer.GetIndexer().Add(&im)
+
+			mon := newMonitor()
+			mon.Spec.Selector = nil
+			mon.Spec.HTTPRoutes = &v1alpha1.HTTPRouteSelector{Selector: newMonitor().Spec.Selector}
+			errEquals(t, nil, op.handleMonitor(t, mon))
+
+			_, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+			if !kerrors.IsNotFound(err) {
+				t.Errorf("Expected the IngressMonitor to be garbage collected, got %v", err)
+			}
+		})
+	})
+
+	t.Run("with HTTPRoutes without the Gateway API", func(t *testing.T) {
+		op := newOperator(t,
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		if op.op.routeInformer != nil {
+			t.Fatalf("Expected the HTTPRoutes not to be watched")
+		}
+
+		mon := newMonitor()
+		mon.Spec.HTTPRoutes = &v1alpha1.HTTPRouteSelector{Selector: newMonitor().Spec.Selector}
+		errEquals(t, nil, op.handleMonitor(t, mon))
+	})
+
+	t.Run("with a pending LoadBalancer Service", func(t *testing.T) {
+		svc := newService()
+		svc.Status.LoadBalancer.Ingress = nil
 
-		if len(imList.Items) != 1 {
-			t.Errorf("Expected 1 IngressMonitor to be created")
-		}
+		op := newOperator(t,
+			withServices(svc),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
 
-		im := imList.Items[0]
+		mon := newMonitor()
+		mon.Spec.Services = &v1alpha1.ServiceSelector{Selector: newMonitor().Spec.Selector}
+		errEquals(t, nil, op.handleMonitor(t, mon))
 
-		expName := "test-go-ingress-testing"
-		strEquals(t, expName, im.Spec.Template.Name)
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
 
-		expURL := "https://api.example.com/test-healthz"
-		strEquals(t, expURL, im.Spec.Template.HTTP.URL)
+		if mon.Status.Services != 1 || mon.Status.Hosts != 0 {
+			t.Errorf("Expected 1 Service without hosts, got %#v", mon.Status)
+		}
 	})
 
 	t.Run("updating an existing monitor", func(t *testing.T) {
@@ -293,22 +1221,27 @@ func TestOperator_SyncMonitor(t *testing.T) {
 
 			mon := newMonitor()
 
-			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(metav1.ListOptions{})
+			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
 			errEquals(t, nil, err)
 
 			if len(imList.Items) != 1 {
-				t.Errorf("Expected 1 IngressMonitor to be available, got %d", len(imList.Items))
+				t.Fatalf("Expected 1 IngressMonitor to be available, got %d", len(imList.Items))
 			}
+			imName := imList.Items[0].Name
 
 			mon.Spec.Selector.MatchLabels["non-existing-key"] = "fake-value"
 			errEquals(t, nil, op.handleMonitor(t, mon))
 
-			imList, err = op.op.imClient.IngressMonitors(mon.Namespace).List(metav1.ListOptions{})
+			imList, err = op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
 			errEquals(t, nil, err)
 
 			if len(imList.Items) != 0 {
 				t.Errorf("Expected 0 IngressMonitor to be available, got %d", len(imList.Items))
 			}
+
+			// The event is recorded on the IngressMonitor, Monitor and Ingress.
+			gcEvent := "Normal GarbageCollected Host api.example.com is no longer selected by Monitor test-monitor, deleting IngressMonitor " + imName
+			eventsEqual(t, op, gcEvent, gcEvent, gcEvent)
 		})
 
 		t.Run("adding an ingress and resyncing", func(t *testing.T) {
@@ -317,7 +1250,8 @@ func TestOperator_SyncMonitor(t *testing.T) {
 
 			ing := newIngress()
 			ing.Name = "new-ingress"
-			newRule := v1beta1.IngressRule{
+			ing.UID = "new-ingress-uid"
+			newRule := networkingv1.IngressRule{
 				Host: "new.api.example.com",
 			}
 			ing.Spec.Rules = append(ing.Spec.Rules, newRule)
@@ -327,7 +1261,7 @@ func TestOperator_SyncMonitor(t *testing.T) {
 			mon := newMonitor()
 			errEquals(t, nil, op.handleMonitor(t, mon))
 
-			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(metav1.ListOptions{})
+			imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
 			errEquals(t, nil, err)
 
 			if len(imList.Items) != 3 {
@@ -337,6 +1271,347 @@ func TestOperator_SyncMonitor(t *testing.T) {
 	})
 }
 
+func TestOperator_ServiceEvents(t *testing.T) {
+	svcMon := newMonitor()
+	svcMon.Name = "service-monitor"
+	svcMon.Spec.Services = &v1alpha1.ServiceSelector{Selector: newMonitor().Spec.Selector}
+
+	setup := func() *operatorWrapper {
+		return newOperator(t, withMonitors(newMonitor(), svcMon))
+	}
+
+	t.Run("adding a selected service", func(t *testing.T) {
+		op := setup()
+
+		op.op.OnAdd(newService())
+		queueEquals(t, op.op.monitorQueue, "testing/service-monitor")
+	})
+
+	t.Run("adding a service which isn't a LoadBalancer", func(t *testing.T) {
+		op := setup()
+
+		svc := newService()
+		svc.Spec.Type = v1.ServiceTypeClusterIP
+
+		op.op.OnAdd(svc)
+		queueEquals(t, op.op.monitorQueue)
+	})
+
+	t.Run("provisioning the LoadBalancer", func(t *testing.T) {
+		op := setup()
+
+		old := newService()
+		old.ResourceVersion = "1"
+		old.Status.LoadBalancer.Ingress = nil
+
+		svc := newService()
+		svc.ResourceVersion = "2"
+
+		op.op.OnUpdate(old, svc)
+		queueEquals(t, op.op.monitorQueue, "testing/service-monitor")
+	})
+
+	t.Run("resyncing a service", func(t *testing.T) {
+		op := setup()
+
+		svc := newService()
+		svc.ResourceVersion = "1"
+
+		op.op.OnUpdate(svc, svc)
+		queueEquals(t, op.op.monitorQueue)
+	})
+
+	t.Run("deleting a selected service", func(t *testing.T) {
+		op := setup()
+
+		op.op.OnDelete(newService())
+		queueEquals(t, op.op.monitorQueue, "testing/service-monitor")
+	})
+}
+
+func TestOperator_GatewayEvents(t *testing.T) {
+	routeMon := newMonitor()
+	routeMon.Name = "route-monitor"
+	routeMon.Spec.HTTPRoutes = &v1alpha1.HTTPRouteSelector{Selector: newMonitor().Spec.Selector}
+
+	nsMon := routeMon.DeepCopy()
+	nsMon.Namespace = "other-namespace"
+
+	setup := func() *operatorWrapper {
+		return newOperator(t, withGateways(), withMonitors(newMonitor(), routeMon, nsMon))
+	}
+
+	t.Run("adding a selected HTTPRoute", func(t *testing.T) {
+		op := setup()
+
+		op.op.OnAdd(newHTTPRoute())
+		queueEquals(t, op.op.monitorQueue, "testing/route-monitor")
+	})
+
+	t.Run("updating a Gateway", func(t *testing.T) {
+		op := setup()
+
+		old := newGateway()
+		old.SetResourceVersion("1")
+
+		gw := newGateway()
+		gw.SetResourceVersion("2")
+
+		op.op.OnUpdate(old, gw)
+		queueEquals(t, op.op.monitorQueue, "other-namespace/route-monitor", "testing/route-monitor")
+	})
+
+	t.Run("resyncing an HTTPRoute", func(t *testing.T) {
+		op := setup()
+
+		route := newHTTPRoute()
+		route.SetResourceVersion("1")
+
+		op.op.OnUpdate(route, route)
+		queueEquals(t, op.op.monitorQueue)
+	})
+
+	t.Run("deleting a selected HTTPRoute", func(t *testing.T) {
+		op := setup()
+
+		op.op.OnDelete(newHTTPRoute())
+		queueEquals(t, op.op.monitorQueue, "testing/route-monitor")
+	})
+}
+
+func TestOperator_IngressEvents(t *testing.T) {
+	otherMon := newMonitor()
+	otherMon.Name = "other-monitor"
+	otherMon.Spec.Selector.MatchLabels = map[string]string{"team": "rustaceans"}
+
+	nsMon := newMonitor()
+	nsMon.Namespace = "other-namespace"
+
+	setup := func() *operatorWrapper {
+		return newOperator(t, withMonitors(newMonitor(), otherMon, nsMon))
+	}
+
+	t.Run("adding a selected ingress", func(t *testing.T) {
+		op := setup()
+
+		op.op.OnAdd(newIngress())
+		queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+	})
+
+	t.Run("adding an ingress which isn't selected", func(t *testing.T) {
+		op := setup()
+
+		ing := newIngress()
+		ing.Labels = map[string]string{"team": "pythonistas"}
+
+		op.op.OnAdd(ing)
+		queueEquals(t, op.op.monitorQueue)
+	})
+
+	t.Run("relabeling an ingress", func(t *testing.T) {
+		op := setup()
+
+		old := newIngress()
+		old.ResourceVersion = "1"
+
+		ing := newIngress()
+		ing.ResourceVersion = "2"
+		ing.Labels = map[string]string{"team": "rustaceans"}
+
+		op.op.OnUpdate(old, ing)
+		queueEquals(t, op.op.monitorQueue, "testing/other-monitor", "testing/test-monitor")
+	})
+
+	t.Run("resyncing an ingress", func(t *testing.T) {
+		op := setup()
+
+		ing := newIngress()
+		ing.ResourceVersion = "1"
+
+		op.op.OnUpdate(ing, ing)
+		queueEquals(t, op.op.monitorQueue)
+	})
+
+	t.Run("deleting a selected ingress", func(t *testing.T) {
+		op := setup()
+
+		op.op.OnDelete(cache.DeletedFinalStateUnknown{
+			Key: "testing/go-ingress",
+			Obj: newIngress(),
+		})
+		queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+	})
+}
+
+func TestOperator_ReferenceEvents(t *testing.T) {
+	otherMon := newMonitor()
+	otherMon.Name = "other-monitor"
+	otherMon.Spec.Provider.Name = "other-provider"
+	otherMon.Spec.Template.Name = "other-template"
+
+	setup := func() *operatorWrapper {
+		return newOperator(t, withMonitors(newMonitor(), otherMon))
+	}
+
+	t.Run("updating a provider", func(t *testing.T) {
+		op := setup()
+
+		old := newProvider()
+		old.ResourceVersion = "1"
+
+		prov := newProvider()
+		prov.ResourceVersion = "2"
+
+		op.op.OnUpdate(old, prov)
+		queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+	})
+
+	t.Run("resyncing a provider", func(t *testing.T) {
+		op := setup()
+
+		prov := newProvider()
+		prov.ResourceVersion = "1"
+
+		op.op.OnUpdate(prov, prov)
+		queueEquals(t, op.op.monitorQueue)
+	})
+
+	t.Run("deleting a provider", func(t *testing.T) {
+		op := setup()
+
+		prov := newProvider()
+		prov.Name = "other-provider"
+
+		op.op.OnDelete(prov)
+		queueEquals(t, op.op.monitorQueue, "testing/other-monitor")
+	})
+
+	t.Run("updating a template", func(t *testing.T) {
+		op := setup()
+
+		old := newTemplate()
+		old.ResourceVersion = "1"
+
+		tpl := newTemplate()
+		tpl.ResourceVersion = "2"
+
+		op.op.OnUpdate(old, tpl)
+		queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+	})
+
+	t.Run("adding a template in another namespace", func(t *testing.T) {
+		op := setup()
+
+		tpl := newTemplate()
+		tpl.Namespace = "other-namespace"
+
+		op.op.OnAdd(tpl)
+		queueEquals(t, op.op.monitorQueue)
+	})
+}
+
+func TestOperator_IngressMonitorEvents(t *testing.T) {
+	t.Run("updating the spec", func(t *testing.T) {
+		op := newOperator(t)
+
+		old := newIngressMonitor()
+		old.ResourceVersion = "1"
+
+		im := newIngressMonitor()
+		im.ResourceVersion = "2"
+		im.Spec.Template.Name = "renamed"
+
+		op.op.OnUpdate(old, im)
+		queueEquals(t, op.op.ingressMonitorQueue, "testing/test-im")
+	})
+
+	t.Run("updating the status", func(t *testing.T) {
+		op := newOperator(t)
+
+		old := newIngressMonitor()
+		old.ResourceVersion = "1"
+
+		im := newIngressMonitor()
+		im.ResourceVersion = "2"
+		im.Status.ID = "12345"
+
+		op.op.OnUpdate(old, im)
+		queueEquals(t, op.op.ingressMonitorQueue)
+	})
+
+	t.Run("marking for deletion", func(t *testing.T) {
+		op := newOperator(t)
+
+		old := newIngressMonitor()
+		old.ResourceVersion = "1"
+
+		im := newIngressMonitor()
+		im.ResourceVersion = "2"
+		now := metav1.Now()
+		im.DeletionTimestamp = &now
+
+		op.op.OnUpdate(old, im)
+		queueEquals(t, op.op.ingressMonitorQueue, "testing/test-im")
+	})
+
+	t.Run("resyncing", func(t *testing.T) {
+		op := newOperator(t)
+
+		im := newIngressMonitor()
+		im.ResourceVersion = "1"
+
+		op.op.OnUpdate(im, im)
+		queueEquals(t, op.op.ingressMonitorQueue, "testing/test-im")
+	})
+
+	t.Run("becoming ready", func(t *testing.T) {
+		op := newOperator(t)
+
+		old := newIngressMonitor()
+		old.ResourceVersion = "1"
+		old.Labels = map[string]string{monitorLabel: "test-monitor"}
+
+		im := old.DeepCopy()
+		im.ResourceVersion = "2"
+		im.Status.Conditions = setCondition(im.Status.Conditions, newCondition(v1alpha1.ConditionReady, true, reasonSynced, ""))
+
+		op.op.OnUpdate(old, im)
+		queueEquals(t, op.op.ingressMonitorQueue)
+		queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+	})
+}
+
+func TestOperator_MonitorEvents(t *testing.T) {
+	t.Run("updating the spec", func(t *testing.T) {
+		op := newOperator(t)
+
+		old := newMonitor()
+		old.ResourceVersion = "1"
+
+		mon := newMonitor()
+		mon.ResourceVersion = "2"
+		mon.Generation = 2
+		mon.Spec.Template.Name = "other-template"
+
+		op.op.OnUpdate(old, mon)
+		queueEquals(t, op.op.monitorQueue, "testing/test-monitor")
+	})
+
+	t.Run("updating the status", func(t *testing.T) {
+		op := newOperator(t)
+
+		old := newMonitor()
+		old.ResourceVersion = "1"
+
+		mon := newMonitor()
+		mon.ResourceVersion = "2"
+		mon.Status.Ingresses = 1
+
+		op.op.OnUpdate(old, mon)
+		queueEquals(t, op.op.monitorQueue)
+	})
+}
+
 type operatorWrapper struct {
 	op         *Operator
 	kubeClient *k8sfake.Clientset
@@ -345,12 +1620,23 @@ type operatorWrapper struct {
 
 type operatorConfig struct {
 	ingresses   []runtime.Object
+	services    []runtime.Object
+	workloads   []runtime.Object
 	kubeObjects []runtime.Object
 
+	watchRollouts bool
+
 	providers       []runtime.Object
 	templates       []runtime.Object
+	monitors        []runtime.Object
 	ingressmonitors []runtime.Object
 	crdObjects      []runtime.Object
+
+	legacyIngressAPI bool
+
+	httpRoutes []runtime.Object
+	gateways   []runtime.Object
+	gatewayAPI bool
 }
 
 type optionFunc func(*operatorConfig)
@@ -362,6 +1648,29 @@ func withIngresses(obj ...runtime.Object) optionFunc {
 	}
 }
 
+func withServices(obj ...runtime.Object) optionFunc {
+	return func(op *operatorConfig) {
+		op.services = append(op.services, obj...)
+		op.kubeObjects = append(op.kubeObjects, obj...)
+	}
+}
+
+// withWorkloads adds the given Deployments and StatefulSets to the cluster and
+// makes the Operator watch rollouts.
+func withWorkloads(obj ...runtime.Object) optionFunc {
+	return func(op *operatorConfig) {
+		op.watchRollouts = true
+		op.workloads = append(op.workloads, obj...)
+		op.kubeObjects = append(op.kubeObjects, obj...)
+	}
+}
+
+func withSecrets(obj ...runtime.Object) optionFunc {
+	return func(op *operatorConfig) {
+		op.kubeObjects = append(op.kubeObjects, obj...)
+	}
+}
+
 func withProviders(obj ...runtime.Object) optionFunc {
 	return func(op *operatorConfig) {
 		op.providers = append(op.providers, obj...)
@@ -376,6 +1685,13 @@ func withTemplates(obj ...runtime.Object) optionFunc {
 	}
 }
 
+func withMonitors(obj ...runtime.Object) optionFunc {
+	return func(op *operatorConfig) {
+		op.monitors = append(op.monitors, obj...)
+		op.crdObjects = append(op.crdObjects, obj...)
+	}
+}
+
 func withIngressMonitors(obj ...runtime.Object) optionFunc {
 	return func(op *operatorConfig) {
 		op.ingressmonitors = append(op.ingressmonitors, obj...)
@@ -383,6 +1699,32 @@ func withIngressMonitors(obj ...runtime.Object) optionFunc {
 	}
 }
 
+// withLegacyIngressAPI sets up the cluster to only serve Ingresses through the
+// extensions/v1beta1 API.
+func withLegacyIngressAPI() optionFunc {
+	return func(op *operatorConfig) {
+		op.legacyIngressAPI = true
+	}
+}
+
+// withHTTPRoutes installs the Gateway API in the cluster and adds the given
+// HTTPRoutes to it.
+func withHTTPRoutes(obj ...runtime.Object) optionFunc {
+	return func(op *operatorConfig) {
+		op.gatewayAPI = true
+		op.httpRoutes = append(op.httpRoutes, obj...)
+	}
+}
+
+// withGateways installs the Gateway API in the cluster and adds the given
+// Gateways to it.
+func withGateways(obj ...runtime.Object) optionFunc {
+	return func(op *operatorConfig) {
+		op.gatewayAPI = true
+		op.gateways = append(op.gateways, obj...)
+	}
+}
+
 func newOperator(t *testing.T, opts ...optionFunc) *operatorWrapper {
 	cfg := new(operatorConfig)
 	for _, opt := range opts {
@@ -393,16 +1735,37 @@ func newOperator(t *testing.T, opts ...optionFunc) *operatorWrapper {
 	mtrc := metrics.New(registry)
 
 	k8sClient := k8sfake.NewSimpleClientset(cfg.kubeObjects...)
+	k8sClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: networkingv1.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{{Name: "networkpolicies"}},
+		},
+	}
+	if !cfg.legacyIngressAPI {
+		k8sClient.Resources[0].APIResources = append(k8sClient.Resources[0].APIResources, metav1.APIResource{Name: "ingresses"})
+	}
+	if cfg.gatewayAPI {
+		k8sClient.Resources = append(k8sClient.Resources, &metav1.APIResourceList{
+			GroupVersion: "gateway.networking.k8s.io/v1",
+			APIResources: []metav1.APIResource{{Name: "httproutes"}, {Name: "gateways"}},
+		})
+	}
 	crdClient := imfake.NewSimpleClientset(cfg.crdObjects...)
 	fact := provider.NewFactory(nil)
 	op, err := NewOperator(
-		k8sClient, crdClient, v1.NamespaceAll,
+		k8sClient, crdClient, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), v1.NamespaceAll,
 		noResyncPeriodFunc(), fact, mtrc,
 	)
 	if err != nil {
 		t.Fatalf("Error creating the operator: %s", err)
 	}
 
+	if cfg.watchRollouts {
+		op.WatchRollouts(noResyncPeriodFunc())
+	}
+
+	op.recorder = record.NewFakeRecorder(100)
+
 	op.ingressMonitorQueue = workqueue.NewNamedRateLimitingQueue(
 		workqueue.NewItemExponentialFailureRateLimiter(0, 0),
 		"IngressMonitors",
@@ -416,6 +1779,27 @@ func newOperator(t *testing.T, opts ...optionFunc) *operatorWrapper {
 		op.ingInformer.GetIndexer().Add(ing)
 	}
 
+	for _, svc := range cfg.services {
+		op.svcInformer.GetIndexer().Add(svc)
+	}
+
+	for _, obj := range cfg.workloads {
+		switch obj.(type) {
+		case *appsv1.Deployment:
+			op.deployInformer.GetIndexer().Add(obj)
+		case *appsv1.StatefulSet:
+			op.stsInformer.GetIndexer().Add(obj)
+		}
+	}
+
+	for _, route := range cfg.httpRoutes {
+		op.routeInformer.GetIndexer().Add(route)
+	}
+
+	for _, gw := range cfg.gateways {
+		op.gwInformer.GetIndexer().Add(gw)
+	}
+
 	for _, prov := range cfg.providers {
 		op.provInformer.GetIndexer().Add(prov)
 	}
@@ -424,6 +1808,10 @@ func newOperator(t *testing.T, opts ...optionFunc) *operatorWrapper {
 		op.mtInformer.GetIndexer().Add(tpl)
 	}
 
+	for _, mon := range cfg.monitors {
+		op.mInformer.GetIndexer().Add(mon)
+	}
+
 	for _, im := range cfg.ingressmonitors {
 		op.imInformer.GetIndexer().Add(im)
 	}
@@ -433,17 +1821,17 @@ func newOperator(t *testing.T, opts ...optionFunc) *operatorWrapper {
 
 func (o *operatorWrapper) handleIngressMonitor(t *testing.T, mon *v1alpha1.IngressMonitor) error {
 	o.op.imInformer.GetIndexer().Add(mon)
-	o.op.imClient.IngressMonitors(mon.Namespace).Create(mon)
-	return o.op.handleIngressMonitor(getKey(t, mon))
+	o.op.imClient.IngressMonitors(mon.Namespace).Create(context.TODO(), mon, metav1.CreateOptions{})
+	return o.op.handleIngressMonitor(context.TODO(), getKey(t, mon))
 }
 
 func (o *operatorWrapper) handleMonitor(t *testing.T, mon *v1alpha1.Monitor) error {
 	o.op.mInformer.GetIndexer().Add(mon)
-	o.op.imClient.Monitors(mon.Namespace).Create(mon)
-	return o.op.handleMonitor(getKey(t, mon))
+	o.op.imClient.Monitors(mon.Namespace).Create(context.TODO(), mon, metav1.CreateOptions{})
+	return o.op.handleMonitor(context.TODO(), getKey(t, mon))
 }
 
-func (o *operatorWrapper) addIngress(ing *v1beta1.Ingress) {
+func (o *operatorWrapper) addIngress(ing *networkingv1.Ingress) {
 	o.op.ingInformer.GetIndexer().Add(ing)
 }
 
@@ -458,6 +1846,53 @@ func getKey(t *testing.T, obj interface{}) string {
 	return key
 }
 
+// eventsEqual drains the recorded events and validates that exactly the
+// expected events have been recorded.
+func eventsEqual(t *testing.T, op *operatorWrapper, exp ...string) {
+	recorder := op.op.recorder.(*record.FakeRecorder)
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	sort.Strings(events)
+	sort.Strings(exp)
+
+	if !reflect.DeepEqual(exp, events) {
+		t.Errorf("Expected events %v, got %v", exp, events)
+	}
+}
+
+// queueEquals drains the given queue and validates that it contained exactly
+// the expected keys.
+func queueEquals(t *testing.T, queue workqueue.RateLimitingInterface, exp ...string) {
+	var keys []string
+	for queue.Len() > 0 {
+		key, _ := queue.Get()
+		queue.Done(key)
+		keys = append(keys, key.(string))
+	}
+	sort.Strings(keys)
+	sort.Strings(exp)
+
+	if !reflect.DeepEqual(exp, keys) {
+		t.Errorf("Expected queue to contain %v, got %v", exp, keys)
+	}
+}
+
+func conditionEquals(t *testing.T, conds []v1alpha1.Condition, tp v1alpha1.ConditionType, status v1.ConditionStatus, reason string) {
+	cond := getCondition(conds, tp)
+	if cond == nil {
+		t.Fatalf("Expected condition %s to be set", tp)
+	}
+
+	if cond.Status != status {
+		t.Errorf("Expected condition %s to have status %s, got %s", tp, status, cond.Status)
+	}
+
+	strEquals(t, reason, cond.Reason, "condition reason")
+}
+
 func strEquals(t *testing.T, exp, act string, str ...string) {
 	prefix := ""
 	for _, s := range str {
@@ -530,31 +1965,94 @@ func newMonitor() *v1alpha1.Monitor {
 	}
 }
 
-func newIngress() *v1beta1.Ingress {
-	return &v1beta1.Ingress{
+func newIngress() *networkingv1.Ingress {
+	return &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "go-ingress",
 			Namespace: "testing",
+			UID:       "go-ingress-uid",
 			Labels: map[string]string{
 				"team":  "gophers",
 				"squad": "operations",
 			},
 		},
-		Spec: v1beta1.IngressSpec{
-			TLS: []v1beta1.IngressTLS{
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{
 				{
 					Hosts: []string{
 						"api.example.com",
 					},
 				},
 			},
-			Rules: []v1beta1.IngressRule{
+			Rules: []networkingv1.IngressRule{
 				{Host: "api.example.com"},
 			},
 		},
 	}
 }
 
+func newService() *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "go-service",
+			Namespace: "testing",
+			UID:       "go-service-uid",
+			Labels: map[string]string{
+				"team": "gophers",
+			},
+		},
+		Spec: v1.ServiceSpec{
+			Type: v1.ServiceTypeLoadBalancer,
+			Ports: []v1.ServicePort{
+				{Name: "http", Port: 8080},
+			},
+		},
+		Status: v1.ServiceStatus{
+			LoadBalancer: v1.LoadBalancerStatus{
+				Ingress: []v1.LoadBalancerIngress{{IP: "203.0.113.10"}},
+			},
+		},
+	}
+}
+
+func newHTTPRoute() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "HTTPRoute",
+		"metadata": map[string]interface{}{
+			"name":      "go-route",
+			"namespace": "testing",
+			"uid":       "go-route-uid",
+			"labels": map[string]interface{}{
+				"team": "gophers",
+			},
+		},
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{
+				map[string]interface{}{"name": "go-gateway"},
+			},
+			"hostnames": []interface{}{"route.example.com", "*.example.com"},
+		},
+	}}
+}
+
+func newGateway() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "Gateway",
+		"metadata": map[string]interface{}{
+			"name":      "go-gateway",
+			"namespace": "testing",
+		},
+		"spec": map[string]interface{}{
+			"listeners": []interface{}{
+				map[string]interface{}{"name": "http", "port": int64(80), "protocol": "HTTP"},
+				map[string]interface{}{"name": "https", "port": int64(443), "protocol": "HTTPS", "hostname": "*.example.com"},
+			},
+		},
+	}}
+}
+
 func newProvider() *v1alpha1.Provider {
 	return &v1alpha1.Provider{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,104 @@
+package ingressmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// pauseReason determines why the check of the IngressMonitor should be paused.
+// It returns the reason for the Paused condition and a description of why the
+// check is paused, or empty strings when it should be running.
+func pauseReason(obj *v1alpha1.IngressMonitor) (string, string) {
+	switch {
+	case obj.Spec.MaintenanceWindow != "":
+		return reasonMaintenanceWindow, fmt.Sprintf("during maintenance window %s", obj.Spec.MaintenanceWindow)
+	case obj.Spec.Rollout != "":
+		return reasonRollout, fmt.Sprintf("during the rollout of %s", obj.Spec.Rollout)
+	case obj.Spec.Paused || hasPausedAnnotation(obj):
+		return reasonPausedByAnnotation, fmt.Sprintf("through the %s annotation", pausedAnnotation)
+	}
+
+	return "", ""
+}
+
+// isPaused determines whether the check of an IngressMonitor is paused with
+// its provider.
+func isPaused(conds []v1alpha1.Condition) bool {
+	cond := getCondition(conds, v1alpha1.ConditionPaused)
+	return cond != nil && cond.Status == v1.ConditionTrue
+}
+
+// pauseMonitor pauses the monitor of the IngressMonitor for the given reason.
+// When the provider can't pause the monitor, it's deleted instead and
+// recreated once it's resumed, which loses its history with the provider.
+func (o *Operator) pauseMonitor(ctx context.Context, cl provider.Interface, obj *v1alpha1.IngressMonitor, reason, why string) error {
+	id := obj.Status.ID
+
+	err := cl.Pause(ctx, id)
+	if errors.Is(err, provider.ErrPauseUnsupported) {
+		if err := cl.Delete(ctx, id); err != nil && !errors.Is(err, provider.ErrNotFound) {
+			return fmt.Errorf("Could not delete monitor '%s' with provider, which can't pause it: %w", id, err)
+		}
+
+		o.recordEvent(obj, v1.EventTypeWarning, eventReasonPauseUnsupported, "Provider %s can't pause monitor %s, deleted it %s until it's resumed", obj.Spec.Provider.Type, id, why)
+		obj.Status.ID = ""
+		obj.Status.Conditions = setCondition(obj.Status.Conditions, pausedCondition(obj, reasonPauseUnsupported, why))
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Could not pause monitor '%s' with provider: %w", id, err)
+	}
+
+	o.recordEvent(obj, v1.EventTypeNormal, eventReasonPaused, "Paused monitor %s with provider %s %s", id, obj.Spec.Provider.Type, why)
+	obj.Status.Conditions = setCondition(obj.Status.Conditions, pausedCondition(obj, reason, why))
+	return nil
+}
+
+// refreshPaused updates the Paused condition of a paused IngressMonitor, it
+// might be paused for another reason than the one it was paused for, e.g. when
+// a maintenance window starts during a rollout. Monitors which have been
+// deleted to pause them keep reporting that.
+func refreshPaused(obj *v1alpha1.IngressMonitor, reason, why string) {
+	if cond := getCondition(obj.Status.Conditions, v1alpha1.ConditionPaused); cond != nil && cond.Reason == reasonPauseUnsupported {
+		reason = reasonPauseUnsupported
+	}
+
+	obj.Status.Conditions = setCondition(obj.Status.Conditions, pausedCondition(obj, reason, why))
+}
+
+// pausedCondition describes why the monitor of the IngressMonitor is paused.
+func pausedCondition(obj *v1alpha1.IngressMonitor, reason, why string) v1alpha1.Condition {
+	if reason == reasonPauseUnsupported {
+		return newCondition(v1alpha1.ConditionPaused, true, reasonPauseUnsupported,
+			fmt.Sprintf("Provider %s can't pause monitors, the monitor is deleted %s and recreated once it's resumed", obj.Spec.Provider.Type, why),
+		)
+	}
+
+	return newCondition(v1alpha1.ConditionPaused, true, reason, "Paused "+why)
+}
+
+// resumeMonitor resumes the paused monitor of the IngressMonitor. Monitors
+// which have been deleted to pause them, or which have been removed from the
+// provider in the meantime, are recreated by the sync which follows.
+func (o *Operator) resumeMonitor(ctx context.Context, cl provider.Interface, obj *v1alpha1.IngressMonitor) error {
+	if obj.Status.ID == "" {
+		o.recordEvent(obj, v1.EventTypeNormal, eventReasonResumed, "Resumed monitor, recreating it with provider %s", obj.Spec.Provider.Type)
+	} else {
+		err := cl.Resume(ctx, obj.Status.ID)
+		if err != nil && !errors.Is(err, provider.ErrNotFound) {
+			return fmt.Errorf("Could not resume monitor '%s' with provider: %w", obj.Status.ID, err)
+		}
+
+		o.recordEvent(obj, v1.EventTypeNormal, eventReasonResumed, "Resumed monitor %s with provider %s", obj.Status.ID, obj.Spec.Provider.Type)
+	}
+
+	obj.Status.Conditions = setCondition(obj.Status.Conditions, newCondition(
+		v1alpha1.ConditionPaused, false, reasonResumed, "",
+	))
+	return nil
+}
@@ -0,0 +1,170 @@
+package ingressmonitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider/fake"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOperator_PauseAnnotation(t *testing.T) {
+	newPausedMonitor := func() *v1alpha1.IngressMonitor {
+		im := newIngressMonitor()
+		im.Annotations = map[string]string{pausedAnnotation: "true"}
+		im.Status.ID = "12345"
+		return im
+	}
+
+	// resync syncs the IngressMonitor again with its latest state.
+	resync := func(t *testing.T, op *operatorWrapper, im *v1alpha1.IngressMonitor) *v1alpha1.IngressMonitor {
+		op.op.imInformer.GetIndexer().Update(im)
+		errEquals(t, nil, op.op.handleIngressMonitor(context.TODO(), getKey(t, im)))
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+		return im
+	}
+
+	t.Run("with a provider which can pause", func(t *testing.T) {
+		op := newOperator(t)
+		prov := &fake.SimpleProvider{
+			UpdateFunc: func(_ context.Context, id string, _ v1alpha1.MonitorTemplateSpec) (string, error) {
+				return id, nil
+			},
+			PauseFunc:  func(context.Context, string) error { return nil },
+			ResumeFunc: func(context.Context, string) error { return nil },
+		}
+		op.op.providerFactory.Register("simple", fake.FactoryFunc(prov))
+
+		im := newPausedMonitor()
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionPaused, v1.ConditionTrue, reasonPausedByAnnotation)
+		strEquals(t, "12345", im.Status.ID, "provider ID")
+		eventsEqual(t, op, "Normal Paused Paused monitor 12345 with provider simple through the ingressmonitor.sphc.io/paused annotation")
+
+		// The condition follows the reason the monitor is paused for.
+		im.Spec.MaintenanceWindow = "nightly"
+		im = resync(t, op, im)
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionPaused, v1.ConditionTrue, reasonMaintenanceWindow)
+		strEquals(t, "Paused during maintenance window nightly", getCondition(im.Status.Conditions, v1alpha1.ConditionPaused).Message, "Paused message")
+		eventsEqual(t, op)
+
+		im.Spec.MaintenanceWindow = ""
+		im.Annotations[pausedAnnotation] = "false"
+		im = resync(t, op, im)
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionPaused, v1.ConditionFalse, reasonResumed)
+		strEquals(t, "12345", im.Status.ID, "provider ID")
+		eventsEqual(t, op, "Normal Resumed Resumed monitor 12345 with provider simple")
+
+		if prov.PauseCount != 1 || prov.ResumeCount != 1 || prov.DeleteCount != 0 {
+			t.Errorf("Expected the monitor to be paused and resumed, got %d pauses, %d resumes and %d deletes", prov.PauseCount, prov.ResumeCount, prov.DeleteCount)
+		}
+	})
+
+	t.Run("with a provider which can't pause", func(t *testing.T) {
+		op := newOperator(t)
+		prov := &fake.SimpleProvider{
+			CreateFunc: func(context.Context, v1alpha1.MonitorTemplateSpec) (string, error) {
+				return "67890", nil
+			},
+			UpdateFunc: func(_ context.Context, id string, _ v1alpha1.MonitorTemplateSpec) (string, error) {
+				return id, nil
+			},
+			DeleteFunc: func(context.Context, string) error { return nil },
+			PauseFunc: func(context.Context, string) error {
+				return provider.ErrPauseUnsupported
+			},
+		}
+		op.op.providerFactory.Register("simple", fake.FactoryFunc(prov))
+
+		im := newPausedMonitor()
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionPaused, v1.ConditionTrue, reasonPauseUnsupported)
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionReady, v1.ConditionTrue, reasonSynced)
+		strEquals(t, "", im.Status.ID, "provider ID")
+		eventsEqual(t, op, "Warning PauseUnsupported Provider simple can't pause monitor 12345, deleted it through the ingressmonitor.sphc.io/paused annotation until it's resumed")
+
+		// The deleted monitor isn't recreated while it's paused.
+		im.Spec.MaintenanceWindow = "nightly"
+		im = resync(t, op, im)
+		eventsEqual(t, op)
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionPaused, v1.ConditionTrue, reasonPauseUnsupported)
+		strEquals(t, "Provider simple can't pause monitors, the monitor is deleted during maintenance window nightly and recreated once it's resumed", getCondition(im.Status.Conditions, v1alpha1.ConditionPaused).Message, "Paused message")
+
+		im.Spec.MaintenanceWindow = ""
+
+		delete(im.Annotations, pausedAnnotation)
+		im = resync(t, op, im)
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionPaused, v1.ConditionFalse, reasonResumed)
+		strEquals(t, "67890", im.Status.ID, "provider ID")
+		eventsEqual(t, op,
+			"Normal Resumed Resumed monitor, recreating it with provider simple",
+			"Normal Created Created monitor 67890 with provider simple",
+		)
+
+		if prov.DeleteCount != 1 || prov.CreateCount != 1 || prov.UpdateCount != 1 {
+			t.Errorf("Expected the monitor to be deleted and recreated, got %d deletes, %d creates and %d updates", prov.DeleteCount, prov.CreateCount, prov.UpdateCount)
+		}
+	})
+
+	t.Run("on a Monitor and Ingress", func(t *testing.T) {
+		paused := newIngress()
+		paused.Annotations = map[string]string{pausedAnnotation: "true"}
+
+		for _, tc := range []struct {
+			name    string
+			monitor map[string]string
+			ingress bool
+		}{
+			{name: "without annotations"},
+			{name: "on the Ingress", ingress: true},
+			{name: "on the Monitor", monitor: map[string]string{pausedAnnotation: "true"}},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				ing := newIngress()
+				if tc.ingress {
+					ing = paused
+				}
+
+				op := newOperator(t,
+					withIngresses(ing),
+					withProviders(newProvider()),
+					withTemplates(newTemplate()),
+				)
+
+				mon := newMonitor()
+				mon.Annotations = tc.monitor
+				errEquals(t, nil, op.handleMonitor(t, mon))
+
+				imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+				errEquals(t, nil, err, "listing the IngressMonitors")
+
+				if len(imList.Items) != 1 {
+					t.Fatalf("Expected 1 IngressMonitor, got %d", len(imList.Items))
+				}
+
+				exp := tc.ingress || tc.monitor != nil
+				if imList.Items[0].Spec.Paused != exp {
+					t.Errorf("Expected paused to be %t, got %t", exp, imList.Items[0].Spec.Paused)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,322 @@
+package ingressmonitor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	av1 "k8s.io/client-go/listers/apps/v1"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	rolloutActionPause              = "Pause"
+	rolloutActionRaiseConfirmations = "RaiseConfirmations"
+)
+
+// reasonProgressDeadlineExceeded is the reason of the Progressing condition of
+// a Deployment whose rollout has stalled.
+const reasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+// statefulSetProgressDeadline is how long an update of a StatefulSet counts
+// as a rollout. StatefulSets don't report stalled rollouts like Deployments
+// do, so the default progress deadline of Deployments is used.
+const statefulSetProgressDeadline = 10 * time.Minute
+
+// WatchRollouts sets up the Operator to watch Deployments and StatefulSets, so
+// Monitors can follow the rollouts of the workloads serving their targets.
+// This needs permission to list and watch them in the whole cluster, which
+// is why it's optional. Without it, rollout policies are ignored. It has to
+// be called before the Operator is started.
+func (o *Operator) WatchRollouts(resync time.Duration) {
+	apps := informers.NewSharedInformerFactory(o.kubeClient, resync).Apps().V1()
+
+	o.deployInformer = apps.Deployments().Informer()
+	o.stsInformer = apps.StatefulSets().Informer()
+	o.deployInformer.AddEventHandler(o)
+	o.stsInformer.AddEventHandler(o)
+	o.deployLister = av1.NewDeploymentLister(o.deployInformer.GetIndexer())
+	o.stsLister = av1.NewStatefulSetLister(o.stsInformer.GetIndexer())
+	o.stsRollouts = newRolloutClock()
+
+	o.informers = append(o.informers,
+		namedInformer{"Deployment", o.deployInformer},
+		namedInformer{"StatefulSet", o.stsInformer},
+	)
+}
+
+// validateRolloutPolicy validates the action of a rollout policy and the
+// settings it needs.
+func validateRolloutPolicy(p *v1alpha1.RolloutPolicy) error {
+	switch p.Action {
+	case rolloutActionPause:
+		return nil
+	case rolloutActionRaiseConfirmations:
+		if p.Confirmations == nil || *p.Confirmations <= 0 {
+			return errors.New("the RaiseConfirmations action needs a positive number of confirmations")
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown action %q, expected %s or %s", p.Action, rolloutActionPause, rolloutActionRaiseConfirmations)
+}
+
+// evaluateRolloutPolicy validates the rollout policy of the Monitor and
+// records the result in the given status. It returns the policy which should
+// be applied, which is nil when rollouts don't affect the checks or aren't
+// watched.
+func (o *Operator) evaluateRolloutPolicy(obj *v1alpha1.Monitor, status *v1alpha1.MonitorStatus) *v1alpha1.RolloutPolicy {
+	status.Rollouts = nil
+	if obj.Spec.Rollouts == nil {
+		status.Conditions = removeCondition(status.Conditions, v1alpha1.ConditionRolloutPolicyValid)
+		return nil
+	}
+
+	if err := validateRolloutPolicy(obj.Spec.Rollouts); err != nil {
+		status.Conditions = setCondition(status.Conditions, newCondition(
+			v1alpha1.ConditionRolloutPolicyValid, false, reasonInvalidRolloutPolicy,
+			fmt.Sprintf("Ignoring invalid rollout policy: %s", err),
+		))
+		return nil
+	}
+
+	if o.deployInformer == nil {
+		status.Conditions = setCondition(status.Conditions, newCondition(
+			v1alpha1.ConditionRolloutPolicyValid, false, reasonRolloutsNotWatched,
+			"Ignoring the rollout policy: the Operator doesn't watch rollouts, run it with --watch-rollouts",
+		))
+		return nil
+	}
+
+	status.Conditions = setCondition(status.Conditions, newCondition(
+		v1alpha1.ConditionRolloutPolicyValid, true, reasonValidRolloutPolicy, "",
+	))
+	return obj.Spec.Rollouts
+}
+
+// applyRolloutPolicy changes the check of a target which is served by a
+// workload that's being rolled out. It returns the value for the Rollout
+// field of the IngressMonitor, which pauses the check.
+func applyRolloutPolicy(policy *v1alpha1.RolloutPolicy, workloads []string, tmpl *v1alpha1.MonitorTemplateSpec) string {
+	if policy == nil || len(workloads) == 0 {
+		return ""
+	}
+
+	switch policy.Action {
+	case rolloutActionPause:
+		return strings.Join(workloads, ", ")
+	case rolloutActionRaiseConfirmations:
+		// Checks which already need more confirmations are left alone.
+		if tmpl.Confirmations == nil || *tmpl.Confirmations < *policy.Confirmations {
+			confirmations := *policy.Confirmations
+			tmpl.Confirmations = &confirmations
+		}
+	}
+
+	return ""
+}
+
+// rollingOutWorkloads returns the Deployments and StatefulSets behind the given
+// Services which are being rolled out, like `Deployment/api`. A workload is
+// behind a Service when the selector of the Service matches the labels of its
+// pods.
+func (o *Operator) rollingOutWorkloads(namespace string, services []string) ([]string, error) {
+	found := map[string]bool{}
+	for _, name := range services {
+		svc, err := o.svcLister.Services(namespace).Get(name)
+		if kerrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("Could not get Service %s:%s: %s", namespace, name, err)
+		}
+
+		// Services without a selector have their endpoints managed by
+		// something else, there's no way to tell which pods they use.
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		sel := labels.SelectorFromSet(svc.Spec.Selector)
+
+		deployments, err := o.deployLister.Deployments(namespace).List(labels.Everything())
+		if err != nil {
+			return nil, fmt.Errorf("Could not list Deployments: %s", err)
+		}
+
+		for _, d := range deployments {
+			if sel.Matches(labels.Set(d.Spec.Template.Labels)) && deploymentRollingOut(d) {
+				found["Deployment/"+d.Name] = true
+			}
+		}
+
+		statefulSets, err := o.stsLister.StatefulSets(namespace).List(labels.Everything())
+		if err != nil {
+			return nil, fmt.Errorf("Could not list StatefulSets: %s", err)
+		}
+
+		for _, sts := range statefulSets {
+			if sel.Matches(labels.Set(sts.Spec.Template.Labels)) && o.statefulSetRollingOut(sts) {
+				found["StatefulSet/"+sts.Name] = true
+			}
+		}
+	}
+
+	var workloads []string
+	for w := range found {
+		workloads = append(workloads, w)
+	}
+	sort.Strings(workloads)
+
+	return workloads, nil
+}
+
+// deploymentRollingOut reports if the Deployment is being rolled out, which
+// is the case until its controller has observed the latest generation and
+// all of its replicas have been updated and are available. Paused rollouts
+// and rollouts which exceeded their progress deadline aren't progressing
+// anymore, the checks should alert for those.
+func deploymentRollingOut(d *appsv1.Deployment) bool {
+	if d.Spec.Paused {
+		return false
+	}
+
+	if d.Status.ObservedGeneration < d.Generation {
+		return true
+	}
+
+	for _, cond := range d.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == reasonProgressDeadlineExceeded {
+			return false
+		}
+	}
+
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+
+	return d.Status.UpdatedReplicas < replicas ||
+		d.Status.Replicas > d.Status.UpdatedReplicas ||
+		d.Status.AvailableReplicas < d.Status.UpdatedReplicas
+}
+
+// statefulSetUpdating reports if the StatefulSet is being updated, which is
+// the case until its controller has observed the latest generation, all of
+// its pods run the latest revision and all of its replicas are ready.
+func statefulSetUpdating(sts *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+
+	return sts.Status.ObservedGeneration < sts.Generation ||
+		sts.Status.UpdateRevision != sts.Status.CurrentRevision ||
+		sts.Status.ReadyReplicas < replicas
+}
+
+// statefulSetRollingOut reports if the StatefulSet is being rolled out. An
+// update only counts as a rollout for statefulSetProgressDeadline after the
+// Operator first saw it, after that it has stalled. The Monitors notice this
+// when they're resynced.
+func (o *Operator) statefulSetRollingOut(sts *appsv1.StatefulSet) bool {
+	key := sts.Namespace + "/" + sts.Name
+	if !statefulSetUpdating(sts) {
+		o.stsRollouts.done(key)
+		return false
+	}
+
+	revision := fmt.Sprintf("%d/%s", sts.Generation, sts.Status.UpdateRevision)
+	return o.stsRollouts.since(key, revision) < statefulSetProgressDeadline
+}
+
+// rolloutClock remembers when the Operator first saw the current rollout of
+// a workload.
+type rolloutClock struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	starts map[string]rolloutStart
+}
+
+type rolloutStart struct {
+	revision string
+	at       time.Time
+}
+
+func newRolloutClock() *rolloutClock {
+	return &rolloutClock{now: time.Now, starts: map[string]rolloutStart{}}
+}
+
+// since returns how long the given revision of the workload has been rolling
+// out. A new revision starts a new rollout.
+func (c *rolloutClock) since(key, revision string) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	start, ok := c.starts[key]
+	if !ok || start.revision != revision {
+		start = rolloutStart{revision: revision, at: now}
+		c.starts[key] = start
+	}
+
+	return now.Sub(start.at)
+}
+
+// done forgets the rollout of the workload.
+func (c *rolloutClock) done(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.starts, key)
+}
+
+// workloadChanged determines if a change to a Deployment or StatefulSet can
+// affect the Monitors with a rollout policy. Only the start and end of a
+// rollout and changes to the labels of its pods matter, the many status
+// updates during a rollout don't. Stalled StatefulSets are picked up when the
+// Monitors are resynced.
+func workloadChanged(old, new interface{}) bool {
+	switch new := new.(type) {
+	case *appsv1.Deployment:
+		old := old.(*appsv1.Deployment)
+		return deploymentRollingOut(old) != deploymentRollingOut(new) ||
+			!labels.Equals(old.Spec.Template.Labels, new.Spec.Template.Labels)
+	case *appsv1.StatefulSet:
+		old := old.(*appsv1.StatefulSet)
+		return statefulSetUpdating(old) != statefulSetUpdating(new) ||
+			!labels.Equals(old.Spec.Template.Labels, new.Spec.Template.Labels)
+	}
+
+	return false
+}
+
+// enqueueMonitorsForWorkload enqueues all the Monitors in the namespace of
+// the given Deployment or StatefulSet which have a rollout policy. Following
+// the workload back to the targets of the Monitors is done when they're
+// synced.
+func (o *Operator) enqueueMonitorsForWorkload(namespace, kind, name string) {
+	mons, err := o.mLister.Monitors(namespace).List(labels.Everything())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"workload_namespace": namespace,
+			"workload_kind":      kind,
+			"workload_name":      name,
+		}).WithError(err).Error("Could not list Monitors for workload")
+		return
+	}
+
+	for _, mon := range mons {
+		if mon.Spec.Rollouts != nil {
+			o.enqueueMonitor(mon)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package ingressmonitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider/fake"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRollingOut(t *testing.T) {
+	replicas := int32(2)
+	stalled := []appsv1.DeploymentCondition{{
+		Type:   appsv1.DeploymentProgressing,
+		Status: v1.ConditionFalse,
+		Reason: reasonProgressDeadlineExceeded,
+	}}
+
+	for _, tc := range []struct {
+		name       string
+		deployment appsv1.DeploymentStatus
+		sts        appsv1.StatefulSetStatus
+		rollingOut bool
+	}{
+		{
+			name:       "when the latest generation is available",
+			deployment: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			sts:        appsv1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 2, CurrentRevision: "v2", UpdateRevision: "v2"},
+		},
+		{
+			name:       "when the latest generation hasn't been observed",
+			deployment: appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			sts:        appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 2, CurrentRevision: "v1", UpdateRevision: "v1"},
+			rollingOut: true,
+		},
+		{
+			name:       "when replicas haven't been updated",
+			deployment: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2},
+			sts:        appsv1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 2, CurrentRevision: "v1", UpdateRevision: "v2"},
+			rollingOut: true,
+		},
+		{
+			name:       "when replicas are unavailable",
+			deployment: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1},
+			sts:        appsv1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 1, CurrentRevision: "v2", UpdateRevision: "v2"},
+			rollingOut: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     tc.deployment,
+			}
+			if deploymentRollingOut(d) != tc.rollingOut {
+				t.Errorf("Expected the Deployment rolling out to be %t", tc.rollingOut)
+			}
+
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+				Status:     tc.sts,
+			}
+			if statefulSetUpdating(sts) != tc.rollingOut {
+				t.Errorf("Expected the StatefulSet updating to be %t", tc.rollingOut)
+			}
+		})
+	}
+
+	t.Run("when the Deployment rollout has stalled", func(t *testing.T) {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2,
+				Conditions: stalled,
+			},
+		}
+		if deploymentRollingOut(d) {
+			t.Errorf("Expected a stalled Deployment not to be rolling out")
+		}
+
+		// A new generation starts a new rollout.
+		d.Generation = 3
+		if !deploymentRollingOut(d) {
+			t.Errorf("Expected a new generation of a stalled Deployment to be rolling out")
+		}
+	})
+
+	t.Run("when the Deployment rollout is paused", func(t *testing.T) {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas, Paused: true},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2},
+		}
+		if deploymentRollingOut(d) {
+			t.Errorf("Expected a paused Deployment not to be rolling out")
+		}
+	})
+
+	t.Run("when the StatefulSet rollout has stalled", func(t *testing.T) {
+		op := newOperator(t, withWorkloads())
+		now := time.Now()
+		op.op.stsRollouts.now = func() time.Time { return now }
+
+		sts := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "testing", Generation: 2},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+			Status:     appsv1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 1, CurrentRevision: "v1", UpdateRevision: "v2"},
+		}
+		if !op.op.statefulSetRollingOut(sts) {
+			t.Errorf("Expected the StatefulSet to be rolling out")
+		}
+
+		now = now.Add(statefulSetProgressDeadline)
+		if op.op.statefulSetRollingOut(sts) {
+			t.Errorf("Expected the StatefulSet not to be rolling out after the progress deadline")
+		}
+
+		// A new revision starts a new rollout.
+		sts.Status.UpdateRevision = "v3"
+		if !op.op.statefulSetRollingOut(sts) {
+			t.Errorf("Expected a new revision of the StatefulSet to be rolling out")
+		}
+	})
+}
+
+func TestOperator_Rollouts(t *testing.T) {
+	ing := newIngress()
+	ing.Spec.DefaultBackend = &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{Name: "api"},
+	}
+
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "testing"},
+		Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "api"}},
+	}
+
+	newDeployment := func(rollingOut bool) *appsv1.Deployment {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "testing", Generation: 2},
+			Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "api", "version": "v2"}},
+				},
+			},
+			Status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		}
+		if rollingOut {
+			d.Status.AvailableReplicas = 0
+		}
+		return d
+	}
+
+	newRolloutMonitor := func(policy *v1alpha1.RolloutPolicy) *v1alpha1.Monitor {
+		mon := newMonitor()
+		mon.Spec.Rollouts = policy
+		return mon
+	}
+
+	syncMonitor := func(t *testing.T, mon *v1alpha1.Monitor, d *appsv1.Deployment) (*v1alpha1.Monitor, *v1alpha1.IngressMonitor) {
+		op := newOperator(t,
+			withIngresses(ing),
+			withServices(svc),
+			withWorkloads(d),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+
+		imList, err := op.op.imClient.IngressMonitors(mon.Namespace).List(context.TODO(), metav1.ListOptions{})
+		errEquals(t, nil, err, "listing the IngressMonitors")
+		if len(imList.Items) != 1 {
+			t.Fatalf("Expected 1 IngressMonitor, got %d", len(imList.Items))
+		}
+
+		return mon, &imList.Items[0]
+	}
+
+	t.Run("pausing during a rollout", func(t *testing.T) {
+		mon, im := syncMonitor(t, newRolloutMonitor(&v1alpha1.RolloutPolicy{Action: "Pause"}), newDeployment(true))
+
+		strEquals(t, "Deployment/api", im.Spec.Rollout, "rollout of the IngressMonitor")
+		if len(mon.Status.Rollouts) != 1 || mon.Status.Rollouts[0] != "Deployment/api" {
+			t.Errorf("Expected the Deployment to be rolling out, got %v", mon.Status.Rollouts)
+		}
+		conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionRolloutPolicyValid, v1.ConditionTrue, reasonValidRolloutPolicy)
+	})
+
+	t.Run("raising confirmations during a rollout", func(t *testing.T) {
+		confirmations := 5
+		_, im := syncMonitor(t, newRolloutMonitor(&v1alpha1.RolloutPolicy{
+			Action: "RaiseConfirmations", Confirmations: &confirmations,
+		}), newDeployment(true))
+
+		strEquals(t, "", im.Spec.Rollout, "rollout of the IngressMonitor")
+		if c := im.Spec.Template.Confirmations; c == nil || *c != 5 {
+			t.Errorf("Expected the confirmations to be raised to 5, got %v", c)
+		}
+	})
+
+	t.Run("after a rollout", func(t *testing.T) {
+		mon, im := syncMonitor(t, newRolloutMonitor(&v1alpha1.RolloutPolicy{Action: "Pause"}), newDeployment(false))
+
+		strEquals(t, "", im.Spec.Rollout, "rollout of the IngressMonitor")
+		if len(mon.Status.Rollouts) != 0 {
+			t.Errorf("Expected no rollouts, got %v", mon.Status.Rollouts)
+		}
+	})
+
+	t.Run("after a rollout has stalled", func(t *testing.T) {
+		d := newDeployment(true)
+		d.Status.Conditions = []appsv1.DeploymentCondition{{
+			Type:   appsv1.DeploymentProgressing,
+			Status: v1.ConditionFalse,
+			Reason: reasonProgressDeadlineExceeded,
+		}}
+		mon, im := syncMonitor(t, newRolloutMonitor(&v1alpha1.RolloutPolicy{Action: "Pause"}), d)
+
+		strEquals(t, "", im.Spec.Rollout, "rollout of the IngressMonitor")
+		if len(mon.Status.Rollouts) != 0 {
+			t.Errorf("Expected no rollouts, got %v", mon.Status.Rollouts)
+		}
+	})
+
+	t.Run("without a rollout policy", func(t *testing.T) {
+		mon, im := syncMonitor(t, newMonitor(), newDeployment(true))
+
+		strEquals(t, "", im.Spec.Rollout, "rollout of the IngressMonitor")
+		if getCondition(mon.Status.Conditions, v1alpha1.ConditionRolloutPolicyValid) != nil {
+			t.Errorf("Expected no RolloutPolicyValid condition")
+		}
+	})
+
+	t.Run("without watching rollouts", func(t *testing.T) {
+		op := newOperator(t,
+			withIngresses(ing),
+			withServices(svc),
+			withProviders(newProvider()),
+			withTemplates(newTemplate()),
+		)
+
+		mon := newRolloutMonitor(&v1alpha1.RolloutPolicy{Action: "Pause"})
+		errEquals(t, nil, op.handleMonitor(t, mon))
+
+		mon, err := op.op.imClient.Monitors(mon.Namespace).Get(context.TODO(), mon.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated Monitor")
+		conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionRolloutPolicyValid, v1.ConditionFalse, reasonRolloutsNotWatched)
+	})
+
+	t.Run("with an invalid rollout policy", func(t *testing.T) {
+		mon, im := syncMonitor(t, newRolloutMonitor(&v1alpha1.RolloutPolicy{Action: "RaiseConfirmations"}), newDeployment(true))
+
+		strEquals(t, "", im.Spec.Rollout, "rollout of the IngressMonitor")
+		if im.Spec.Template.Confirmations != nil {
+			t.Errorf("Expected the confirmations to be left alone")
+		}
+		conditionEquals(t, mon.Status.Conditions, v1alpha1.ConditionRolloutPolicyValid, v1.ConditionFalse, reasonInvalidRolloutPolicy)
+	})
+
+	t.Run("pausing the IngressMonitor", func(t *testing.T) {
+		op := newOperator(t)
+		prov := &fake.SimpleProvider{
+			UpdateFunc: func(_ context.Context, id string, _ v1alpha1.MonitorTemplateSpec) (string, error) {
+				return id, nil
+			},
+			PauseFunc: func(context.Context, string) error {
+				return nil
+			},
+		}
+		op.op.providerFactory.Register("simple", fake.FactoryFunc(prov))
+
+		im := newIngressMonitor()
+		im.Spec.Rollout = "Deployment/api"
+		im.Status.ID = "12345"
+		errEquals(t, nil, op.handleIngressMonitor(t, im))
+
+		im, err := op.op.imClient.IngressMonitors(im.Namespace).Get(context.TODO(), im.Name, metav1.GetOptions{})
+		errEquals(t, nil, err, "getting updated IngressMonitor")
+
+		conditionEquals(t, im.Status.Conditions, v1alpha1.ConditionPaused, v1.ConditionTrue, reasonRollout)
+		eventsEqual(t, op, "Normal Paused Paused monitor 12345 with provider simple during the rollout of Deployment/api")
+	})
+}
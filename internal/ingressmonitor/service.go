@@ -0,0 +1,163 @@
+package ingressmonitor
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// serviceSchemeAnnotation can be set on a Service to configure the scheme which
+// is used to monitor it. By default, the scheme is derived from the port.
+const serviceSchemeAnnotation = "ingressmonitor.sphc.io/scheme"
+
+// selectedServices lists the Services of type LoadBalancer which are selected
+// by the Monitor.
+func (o *Operator) selectedServices(obj *v1alpha1.Monitor) ([]*v1.Service, error) {
+	if obj.Spec.Services == nil {
+		return nil, nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(obj.Spec.Services.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("Could not create service selector for %s:%s: %s", obj.Namespace, obj.Name, err)
+	}
+
+	serviceList, err := o.svcLister.Services(obj.Namespace).List(sel)
+	if err != nil {
+		return nil, fmt.Errorf("Could not list Services: %s", err)
+	}
+
+	var selected []*v1.Service
+	for _, svc := range serviceList {
+		if svc.Spec.Type == v1.ServiceTypeLoadBalancer {
+			selected = append(selected, svc)
+		}
+	}
+
+	return selected, nil
+}
+
+// monitorSelectsService reports if the Monitor selects the given Service.
+func monitorSelectsService(mon *v1alpha1.Monitor, svc *v1.Service) bool {
+	if mon.Spec.Services == nil || svc.Spec.Type != v1.ServiceTypeLoadBalancer {
+		return false
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(mon.Spec.Services.Selector)
+	if err != nil {
+		return false
+	}
+
+	return sel.Matches(labels.Set(svc.Labels))
+}
+
+// serviceTargets returns a target for every address of the LoadBalancer of the
+// given Service. Services which haven't been assigned an address yet don't
+// have any targets.
+func serviceTargets(svc *v1.Service, port *intstr.IntOrString) []target {
+	svcPort, ok := servicePort(svc, port)
+	if !ok {
+		return nil
+	}
+
+	scheme := serviceScheme(svc, svcPort)
+	ref := *metav1.NewControllerRef(svc, v1.SchemeGroupVersion.WithKind("Service"))
+
+	var targets []target
+	for _, lb := range svc.Status.LoadBalancer.Ingress {
+		host := lb.Hostname
+		if host == "" {
+			host = lb.IP
+		}
+		if host == "" {
+			continue
+		}
+
+		addr := host
+		if !isDefaultPort(scheme, svcPort.Port) {
+			addr = net.JoinHostPort(host, strconv.Itoa(int(svcPort.Port)))
+		} else if strings.Contains(host, ":") {
+			addr = "[" + host + "]"
+		}
+
+		targets = append(targets, target{
+			owner:    svc,
+			ownerRef: ref,
+			// The kind is part of the hash so a Service can't end up with
+			// the same IngressMonitor as an Ingress with the same name.
+			name:     fmt.Sprintf("%s-%s", svc.Name, shortHash("Service/"+addr, 16)),
+			host:     host,
+			baseURL:  fmt.Sprintf("%s://%s", scheme, addr),
+			services: []string{svc.Name},
+			labels:   targetLabels(serviceLabel, svc.Name, host),
+		})
+	}
+
+	return targets
+}
+
+// servicePort finds the port of the Service which should be monitored. When
+// no port is configured, the first port of the Service is used.
+func servicePort(svc *v1.Service, port *intstr.IntOrString) (v1.ServicePort, bool) {
+	if len(svc.Spec.Ports) == 0 {
+		return v1.ServicePort{}, false
+	}
+
+	if port == nil {
+		return svc.Spec.Ports[0], true
+	}
+
+	for _, p := range svc.Spec.Ports {
+		if port.Type == intstr.String && p.Name == port.StrVal {
+			return p, true
+		}
+
+		if port.Type == intstr.Int && p.Port == port.IntVal {
+			return p, true
+		}
+	}
+
+	return v1.ServicePort{}, false
+}
+
+// serviceScheme determines the scheme which should be used to monitor the
+// given port. The scheme annotation takes precedence, after which the
+// application protocol, name and number of the port are used.
+func serviceScheme(svc *v1.Service, port v1.ServicePort) string {
+	if scheme, ok := httpScheme(svc.Annotations[serviceSchemeAnnotation]); ok {
+		return scheme
+	}
+
+	if port.AppProtocol != nil {
+		if scheme, ok := httpScheme(*port.AppProtocol); ok {
+			return scheme
+		}
+	}
+
+	if port.Name == "https" || port.Port == 443 {
+		return "https"
+	}
+
+	return "http"
+}
+
+func httpScheme(s string) (string, bool) {
+	switch s = strings.ToLower(s); s {
+	case "http", "https":
+		return s, true
+	}
+
+	return "", false
+}
+
+func isDefaultPort(scheme string, port int32) bool {
+	return (scheme == "http" && port == 80) || (scheme == "https" && port == 443)
+}
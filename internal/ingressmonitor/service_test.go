@@ -0,0 +1,89 @@
+package ingressmonitor
+
+import (
+	"testing"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestServiceTargets(t *testing.T) {
+	tcs := []struct {
+		name string
+		port *intstr.IntOrString
+		svc  func(*v1.Service)
+		urls []string
+	}{
+		{"with the first port", nil, func(*v1.Service) {}, []string{"http://203.0.113.10:8080"}},
+		{"with the default HTTP port", nil, func(svc *v1.Service) {
+			svc.Spec.Ports[0].Port = 80
+		}, []string{"http://203.0.113.10"}},
+		{"with the default HTTPS port", nil, func(svc *v1.Service) {
+			svc.Spec.Ports[0].Port = 443
+		}, []string{"https://203.0.113.10"}},
+		{"with a port named https", nil, func(svc *v1.Service) {
+			svc.Spec.Ports[0].Name = "https"
+		}, []string{"https://203.0.113.10:8080"}},
+		{"with an application protocol", nil, func(svc *v1.Service) {
+			svc.Spec.Ports[0].AppProtocol = ptrString("HTTPS")
+		}, []string{"https://203.0.113.10:8080"}},
+		{"with the scheme annotation", nil, func(svc *v1.Service) {
+			svc.Annotations = map[string]string{serviceSchemeAnnotation: "https"}
+			svc.Spec.Ports[0].Port = 443
+			svc.Spec.Ports[0].Name = "web"
+		}, []string{"https://203.0.113.10"}},
+		{"with an invalid scheme annotation", nil, func(svc *v1.Service) {
+			svc.Annotations = map[string]string{serviceSchemeAnnotation: "ftp"}
+		}, []string{"http://203.0.113.10:8080"}},
+		{"with a named port", portPtr(intstr.FromString("admin")), func(svc *v1.Service) {
+			svc.Spec.Ports = append(svc.Spec.Ports, v1.ServicePort{Name: "admin", Port: 9090})
+		}, []string{"http://203.0.113.10:9090"}},
+		{"with a port number", portPtr(intstr.FromInt(9090)), func(svc *v1.Service) {
+			svc.Spec.Ports = append(svc.Spec.Ports, v1.ServicePort{Name: "admin", Port: 9090})
+		}, []string{"http://203.0.113.10:9090"}},
+		{"with an unknown port", portPtr(intstr.FromString("admin")), func(*v1.Service) {}, nil},
+		{"with a hostname", nil, func(svc *v1.Service) {
+			svc.Status.LoadBalancer.Ingress = []v1.LoadBalancerIngress{
+				{IP: "203.0.113.10", Hostname: "lb.example.com"},
+			}
+		}, []string{"http://lb.example.com:8080"}},
+		{"with multiple addresses", nil, func(svc *v1.Service) {
+			svc.Status.LoadBalancer.Ingress = append(svc.Status.LoadBalancer.Ingress, v1.LoadBalancerIngress{IP: "2001:db8::1"})
+		}, []string{"http://203.0.113.10:8080", "http://[2001:db8::1]:8080"}},
+		{"without an address", nil, func(svc *v1.Service) {
+			svc.Status.LoadBalancer.Ingress = nil
+		}, nil},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := newService()
+			tc.svc(svc)
+
+			targets := serviceTargets(svc, tc.port)
+			if len(targets) != len(tc.urls) {
+				t.Fatalf("Expected %d targets, got %d", len(tc.urls), len(targets))
+			}
+
+			for i, target := range targets {
+				strEquals(t, tc.urls[i], target.baseURL, "URL")
+				strEquals(t, "go-service", target.labels[serviceLabel], "service label")
+				strEquals(t, "go-service-uid", string(target.ownerRef.UID), "owner reference")
+			}
+		})
+	}
+
+	t.Run("with an IPv6 address", func(t *testing.T) {
+		svc := newService()
+		svc.Status.LoadBalancer.Ingress = []v1.LoadBalancerIngress{{IP: "2001:db8::1"}}
+
+		targets := serviceTargets(svc, nil)
+		if _, ok := targets[0].labels[ingressHostLabel]; ok {
+			t.Errorf("Expected the host label not to be set for an IPv6 address")
+		}
+	})
+}
+
+func portPtr(port intstr.IntOrString) *intstr.IntOrString {
+	return &port
+}
@@ -0,0 +1,202 @@
+package ingressmonitor
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// target is a single endpoint selected by a Monitor. Every target gets its own
+// IngressMonitor, which is controlled by the object the target is derived
+// from.
+type target struct {
+	// owner is the object the target is derived from.
+	owner metav1.Object
+	// ownerRef is the controller reference to the owner which is set on the
+	// IngressMonitor, so it gets garbage collected together with its owner.
+	ownerRef metav1.OwnerReference
+
+	// name is the name of the IngressMonitor for this target.
+	name string
+	// host is the host which is monitored.
+	host string
+	// path is the path on the host which is monitored. It's only set when the
+	// Monitor monitors every path of an Ingress.
+	path string
+	// baseURL is the scheme, host and optional port of the target. The
+	// endpoint of the MonitorTemplate is appended to it.
+	baseURL string
+	// tlsSecret is the Secret which holds the certificate of the host, if
+	// it's known.
+	tlsSecret string
+	// records are the addresses the host should resolve to, if they're
+	// known.
+	records []string
+	// services are the Services in the namespace of the owner which serve
+	// the target. The workloads behind them are followed for rollouts.
+	services []string
+
+	// labels link the IngressMonitor to its owner.
+	labels map[string]string
+}
+
+// selection is the result of selecting all targets for a Monitor.
+type selection struct {
+	targets    []target
+	ingresses  int32
+	services   int32
+	httpRoutes int32
+}
+
+// selectedTargets lists the targets of all Ingresses, Services and HTTPRoutes
+// which are selected by the Monitor.
+func (o *Operator) selectedTargets(obj *v1alpha1.Monitor) (*selection, error) {
+	ingLabels, err := metav1.LabelSelectorAsSelector(obj.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("Could not create label selector for %s:%s: %s", obj.Namespace, obj.Name, err)
+	}
+
+	ingressList, err := o.selectedIngresses(obj, ingLabels)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceList, err := o.selectedServices(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	routeList, err := o.selectedRoutes(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	sel := &selection{
+		ingresses:  int32(len(ingressList)),
+		services:   int32(len(serviceList)),
+		httpRoutes: int32(len(routeList)),
+	}
+
+	var targets []target
+	for _, ing := range ingressList {
+		targets = append(targets, ingressTargets(ing, o.ingressGV, obj.Spec.PerPath)...)
+	}
+	for _, svc := range serviceList {
+		targets = append(targets, serviceTargets(svc, obj.Spec.Services.Port)...)
+	}
+	for _, route := range routeList {
+		routeTargets, err := o.routeTargets(route)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, routeTargets...)
+	}
+
+	// Missing MonitorTemplates are reported by the Monitor itself.
+	var spec *v1alpha1.MonitorTemplateSpec
+	if tmpl, err := o.mtLister.MonitorTemplates(obj.Namespace).Get(obj.Spec.Template.Name); err == nil {
+		spec = &tmpl.Spec
+	}
+
+	// Resources which have monitoring disabled are still counted as selected,
+	// but don't get any IngressMonitors.
+	for _, t := range targets {
+		if !isDisabled(t.owner) && t.checkable(spec) {
+			sel.targets = append(sel.targets, t)
+		}
+	}
+
+	return sel, nil
+}
+
+// checkable reports if the target can be checked with the given
+// MonitorTemplate. Certificates can only be checked for targets which are
+// served over TLS, DNS records only for hostnames of which the expected
+// records are known.
+func (t target) checkable(spec *v1alpha1.MonitorTemplateSpec) bool {
+	if spec == nil {
+		return true
+	}
+
+	switch spec.Type {
+	case "Certificate":
+		return t.tls()
+	case "DNS":
+		if net.ParseIP(t.host) != nil {
+			return false
+		}
+		return len(t.records) > 0 || (spec.DNS != nil && len(spec.DNS.ExpectedRecords) > 0)
+	}
+
+	return true
+}
+
+// validateSelectors validates all the label selectors of the Monitor.
+func validateSelectors(obj *v1alpha1.Monitor) error {
+	if _, err := metav1.LabelSelectorAsSelector(obj.Spec.Selector); err != nil {
+		return err
+	}
+
+	if obj.Spec.Services != nil {
+		if _, err := metav1.LabelSelectorAsSelector(obj.Spec.Services.Selector); err != nil {
+			return fmt.Errorf("services: %s", err)
+		}
+	}
+
+	if obj.Spec.HTTPRoutes != nil {
+		if _, err := metav1.LabelSelectorAsSelector(obj.Spec.HTTPRoutes.Selector); err != nil {
+			return fmt.Errorf("httpRoutes: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// url returns the URL which should be monitored for the target, with the given
+// endpoint relative to the path of the target.
+func (t target) url(endpoint string) string {
+	return t.baseURL + strings.TrimSuffix(t.path, "/") + endpoint
+}
+
+// tls reports if the target is served over TLS.
+func (t target) tls() bool {
+	return strings.HasPrefix(t.baseURL, "https://")
+}
+
+// port returns the port of the target, which is the default port of its
+// scheme unless the base URL has an explicit port.
+func (t target) port() int32 {
+	u, err := url.Parse(t.baseURL)
+	if err != nil {
+		return 0
+	}
+
+	if p, err := strconv.Atoi(u.Port()); err == nil {
+		return int32(p)
+	}
+
+	if u.Scheme == "https" {
+		return 443
+	}
+
+	return 80
+}
+
+// targetLabels returns the labels which link an IngressMonitor to the owner of
+// its target. The host label is only set when the host is a valid label value,
+// which isn't the case for IPv6 addresses.
+func targetLabels(ownerLabel, ownerName, host string) map[string]string {
+	lbls := map[string]string{ownerLabel: ownerName}
+	if len(validation.IsValidLabelValue(host)) == 0 {
+		lbls[ingressHostLabel] = host
+	}
+
+	return lbls
+}
@@ -9,6 +9,7 @@ const (
 	ingressMonitorSyncGauge    = "ingressmonitor_ingressmonitor_sync_total"
 	ingressMonitorFailedGauge  = "ingressmonitor_ingressmonitor_failed_total"
 	ingressMonitorSuccessGauge = "ingressmonitor_ingressmonitor_success_total"
+	leaderGauge                = "ingressmonitor_leader"
 )
 
 // Namespaced represent a type which has a namespace attached to it.
@@ -22,6 +23,7 @@ type Metrics struct {
 	ingressMonitorSyncGauge    *prometheus.GaugeVec
 	ingressMonitorFailedGauge  *prometheus.GaugeVec
 	ingressMonitorSuccessGauge *prometheus.GaugeVec
+	leaderGauge                prometheus.Gauge
 }
 
 // IngressMonitorMetric represents a metric which will be used to capture
@@ -56,6 +58,16 @@ func (m *Metrics) SyncIngressMonitor(obj IngressMonitorMetric) {
 	m.ingressMonitorSyncGauge.WithLabelValues(obj.Namespace).Inc()
 }
 
+// SetLeader records whether or not this replica of the Operator is the leader
+// which syncs the monitors with the providers.
+func (m *Metrics) SetLeader(leader bool) {
+	if leader {
+		m.leaderGauge.Set(1)
+	} else {
+		m.leaderGauge.Set(0)
+	}
+}
+
 // New returns a new metrics handler which registers all it's metrics with the
 // specified prometheus Registry to broadcast it's captured values.
 func New(reg *prometheus.Registry) *Metrics {
@@ -91,6 +103,13 @@ func New(reg *prometheus.Registry) *Metrics {
 			},
 			[]string{"namespace"},
 		),
+
+		leaderGauge: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: leaderGauge,
+				Help: "Whether or not this replica is the leader which syncs the Ingress Monitors",
+			},
+		),
 	}
 
 	m.register(reg)
@@ -103,5 +122,6 @@ func (m *Metrics) register(reg *prometheus.Registry) {
 		m.ingressMonitorSyncGauge,
 		m.ingressMonitorFailedGauge,
 		m.ingressMonitorSuccessGauge,
+		m.leaderGauge,
 	)
 }
@@ -161,6 +161,37 @@ func TestMetrics_IngressMonitor(t *testing.T) {
 	})
 }
 
+func TestMetrics_Leader(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := New(reg)
+
+	leader := func() float64 {
+		gathering, err := reg.Gather()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, gath := range gathering {
+			if gath.GetName() == leaderGauge {
+				return gath.Metric[0].GetGauge().GetValue()
+			}
+		}
+
+		t.Fatalf("Expected the %s metric to be gathered", leaderGauge)
+		return 0
+	}
+
+	m.SetLeader(true)
+	if v := leader(); v != 1 {
+		t.Errorf("Expected the leader metric to be 1, got %v", v)
+	}
+
+	m.SetLeader(false)
+	if v := leader(); v != 0 {
+		t.Errorf("Expected the leader metric to be 0, got %v", v)
+	}
+}
+
 func labelPair(name, value string) *mprom.LabelPair {
 	return &mprom.LabelPair{Name: ptrString(name), Value: ptrString(value)}
 }
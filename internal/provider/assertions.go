@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+)
+
+// UnsupportedAssertionsError is returned by a provider, together with the ID
+// of the monitor, when it set up the monitor without the assertions it can't
+// express. The monitor itself is in sync, so the Operator reports these
+// assertions instead of retrying.
+type UnsupportedAssertionsError struct {
+	Provider   string
+	Assertions []string
+}
+
+func (e *UnsupportedAssertionsError) Error() string {
+	return fmt.Sprintf("provider %s can't express the assertions %s", e.Provider, strings.Join(e.Assertions, ", "))
+}
+
+// UnsupportedAssertions returns an UnsupportedAssertionsError for the given
+// assertions, or nil when there are none.
+func UnsupportedAssertions(name string, assertions []string) error {
+	if len(assertions) == 0 {
+		return nil
+	}
+
+	return &UnsupportedAssertionsError{Provider: name, Assertions: assertions}
+}
+
+// ValidateAssertion validates that exactly one check is set in the given
+// assertion and that it's valid.
+func ValidateAssertion(a v1alpha1.Assertion) error {
+	var set int
+	if a.Regex != "" {
+		set++
+		if _, err := regexp.Compile(a.Regex); err != nil {
+			return fmt.Errorf("invalid regex: %s", err)
+		}
+	}
+
+	if a.JSONPath != nil {
+		set++
+		if a.JSONPath.Path == "" {
+			return errors.New("jsonPath assertions need a path")
+		}
+	}
+
+	if a.Header != nil {
+		set++
+		if a.Header.Name == "" {
+			return errors.New("header assertions need a name")
+		}
+	}
+
+	if a.MaxResponseTime != "" {
+		set++
+		if _, err := MaxResponseTime(a); err != nil {
+			return err
+		}
+	}
+
+	if set != 1 {
+		return fmt.Errorf("assertions should set exactly one check, got %d", set)
+	}
+
+	return nil
+}
+
+// MaxResponseTime returns the maximum response time of the given assertion.
+func MaxResponseTime(a v1alpha1.Assertion) (time.Duration, error) {
+	d, err := time.ParseDuration(a.MaxResponseTime)
+	if err != nil {
+		return 0, err
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("maximum response time should be positive, got %s", d)
+	}
+
+	return d, nil
+}
+
+// DescribeAssertion returns a short description of the given assertion, which
+// is used to report assertions a provider can't express.
+func DescribeAssertion(a v1alpha1.Assertion) string {
+	switch {
+	case a.Regex != "":
+		return fmt.Sprintf("regex %q", a.Regex)
+	case a.JSONPath != nil:
+		return fmt.Sprintf("jsonPath %s equals %q", a.JSONPath.Path, a.JSONPath.Equals)
+	case a.Header != nil && a.Header.Equals != "":
+		return fmt.Sprintf("header %s equals %q", a.Header.Name, a.Header.Equals)
+	case a.Header != nil:
+		return fmt.Sprintf("header %s is present", a.Header.Name)
+	case a.MaxResponseTime != "":
+		return fmt.Sprintf("maxResponseTime %s", a.MaxResponseTime)
+	}
+
+	return "empty assertion"
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned by a provider when the monitor with the given
+	// ID doesn't exist with the provider. When updating a monitor, the
+	// Operator recreates it.
+	ErrNotFound = errors.New("the monitor can't be found with the provider")
+
+	// ErrNoChange is returned by a provider when an update didn't change the
+	// monitor. The Operator treats this as a successful sync.
+	ErrNoChange = errors.New("the monitor is already up to date")
+
+	// ErrUnauthorized is returned by a provider when it rejects the
+	// configured credentials.
+	ErrUnauthorized = errors.New("the provider rejected the credentials")
+
+	// ErrRateLimited is returned by a provider when it's rate limiting the
+	// Operator. Providers should return a RateLimitError, which matches
+	// ErrRateLimited, so the Operator knows when to retry.
+	ErrRateLimited = errors.New("the provider is rate limiting requests")
+
+	// ErrInvalidSpec is returned by a provider when it can't use the
+	// configuration of the monitor. Retrying won't help, so the Operator waits
+	// for the IngressMonitor to change instead.
+	ErrInvalidSpec = errors.New("the provider can't use the monitor configuration")
+
+	// ErrUnsupportedType is returned by a provider when it doesn't support the
+	// type of check of the monitor. Providers should return an
+	// UnsupportedTypeError, which matches both ErrUnsupportedType and
+	// ErrInvalidSpec.
+	ErrUnsupportedType = errors.New("the provider doesn't support the type of check")
+
+	// ErrPauseUnsupported is returned by a provider when it can't pause or
+	// resume the monitor. The check keeps running with the provider.
+	ErrPauseUnsupported = errors.New("the provider can't pause the monitor")
+)
+
+// RateLimitError is returned by a provider when it's rate limiting the
+// Operator. RetryAfter is the duration after which the call can be retried.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrRateLimited, e.RetryAfter)
+}
+
+// Is makes a RateLimitError match ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// UnsupportedTypeError is returned by a provider when it doesn't support the
+// type of check of the monitor.
+type UnsupportedTypeError struct {
+	Provider string
+	Type     string
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("provider %s doesn't support %s checks", e.Provider, e.Type)
+}
+
+// Is makes an UnsupportedTypeError match ErrUnsupportedType and
+// ErrInvalidSpec, as retrying won't help.
+func (e *UnsupportedTypeError) Is(target error) bool {
+	return target == ErrUnsupportedType || target == ErrInvalidSpec
+}
+
+// InvalidSpec wraps the given error so it matches ErrInvalidSpec, while
+// keeping the details of why the configuration can't be used.
+func InvalidSpec(err error) error {
+	return fmt.Errorf("%w: %s", ErrInvalidSpec, err)
+}
@@ -1,6 +1,8 @@
 package fake
 
 import (
+	"context"
+
 	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
 	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
 	"k8s.io/client-go/kubernetes"
@@ -8,32 +10,64 @@ import (
 
 // SimpleProvider represents a provider which is useful for testing purposes.
 type SimpleProvider struct {
-	CreateFunc  func(v1alpha1.MonitorTemplateSpec) (string, error)
+	CreateFunc  func(context.Context, v1alpha1.MonitorTemplateSpec) (string, error)
 	CreateCount int
 
-	DeleteFunc  func(string) error
+	DeleteFunc  func(context.Context, string) error
 	DeleteCount int
 
-	UpdateFunc  func(string, v1alpha1.MonitorTemplateSpec) (string, error)
+	UpdateFunc  func(context.Context, string, v1alpha1.MonitorTemplateSpec) (string, error)
 	UpdateCount int
+
+	ListFunc  func(context.Context) ([]provider.Monitor, error)
+	ListCount int
+
+	PauseFunc  func(context.Context, string) error
+	PauseCount int
+
+	ResumeFunc  func(context.Context, string) error
+	ResumeCount int
 }
 
 // Create calls the specified CreateFunc in the SimpleProvider.
-func (fp *SimpleProvider) Create(im v1alpha1.MonitorTemplateSpec) (string, error) {
+func (fp *SimpleProvider) Create(ctx context.Context, im v1alpha1.MonitorTemplateSpec) (string, error) {
 	fp.CreateCount++
-	return fp.CreateFunc(im)
+	return fp.CreateFunc(ctx, im)
 }
 
 // Delete calls the specified DeleteFunc in the SimpleProvider.
-func (fp *SimpleProvider) Delete(id string) error {
+func (fp *SimpleProvider) Delete(ctx context.Context, id string) error {
 	fp.DeleteCount++
-	return fp.DeleteFunc(id)
+	return fp.DeleteFunc(ctx, id)
 }
 
 // Update calls the specified UpdateFunc in the SimpleProvider.
-func (fp *SimpleProvider) Update(id string, im v1alpha1.MonitorTemplateSpec) (string, error) {
+func (fp *SimpleProvider) Update(ctx context.Context, id string, im v1alpha1.MonitorTemplateSpec) (string, error) {
 	fp.UpdateCount++
-	return fp.UpdateFunc(id, im)
+	return fp.UpdateFunc(ctx, id, im)
+}
+
+// List calls the specified ListFunc in the SimpleProvider. Without a ListFunc,
+// the SimpleProvider has no monitors.
+func (fp *SimpleProvider) List(ctx context.Context) ([]provider.Monitor, error) {
+	fp.ListCount++
+	if fp.ListFunc == nil {
+		return nil, nil
+	}
+
+	return fp.ListFunc(ctx)
+}
+
+// Pause calls the specified PauseFunc in the SimpleProvider.
+func (fp *SimpleProvider) Pause(ctx context.Context, id string) error {
+	fp.PauseCount++
+	return fp.PauseFunc(ctx, id)
+}
+
+// Resume calls the specified ResumeFunc in the SimpleProvider.
+func (fp *SimpleProvider) Resume(ctx context.Context, id string) error {
+	fp.ResumeCount++
+	return fp.ResumeFunc(ctx, id)
 }
 
 // FactoryFunc is used to register the factory in a given test so we can use it
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+)
+
+// Regions are the provider-neutral regions a check can run from. Providers
+// map them to their own locations.
+var Regions = []string{
+	"europe",
+	"north-america",
+	"south-america",
+	"asia",
+	"oceania",
+	"africa",
+}
+
+// ValidateLocations validates that the given locations only use known
+// regions and that the minimum number of failing locations is positive.
+func ValidateLocations(loc *v1alpha1.LocationTemplate) error {
+	if loc == nil {
+		return nil
+	}
+
+	for _, region := range loc.Regions {
+		if !knownRegion(region) {
+			return fmt.Errorf("unknown region %q, should be one of %v", region, Regions)
+		}
+	}
+
+	if loc.MinFailing != nil && *loc.MinFailing < 1 {
+		return errors.New("the minimum number of failing locations should be positive")
+	}
+
+	return nil
+}
+
+func knownRegion(region string) bool {
+	for _, r := range Regions {
+		if r == region {
+			return true
+		}
+	}
+
+	return false
+}
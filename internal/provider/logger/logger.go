@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"context"
+
 	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
 	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
 	"github.com/sirupsen/logrus"
@@ -20,23 +22,81 @@ func FactoryFunc(_ kubernetes.Interface, _ v1alpha1.NamespacedProvider) (provide
 
 type prov struct{}
 
+// validate rejects the checks the logger can't describe. The logger only
+// knows about HTTP checks, but runs them from any region.
+func validate(ts v1alpha1.MonitorTemplateSpec) error {
+	if ts.Type != "" && ts.Type != "HTTP" {
+		return &provider.UnsupportedTypeError{Provider: "Logger", Type: ts.Type}
+	}
+
+	if err := provider.ValidateLocations(ts.Locations); err != nil {
+		return provider.InvalidSpec(err)
+	}
+
+	return nil
+}
+
+// assertionsError reports the assertions of the spec, the logger can't
+// express any of them.
+func assertionsError(ts v1alpha1.MonitorTemplateSpec) error {
+	if ts.HTTP == nil {
+		return nil
+	}
+
+	var unsupported []string
+	for _, a := range ts.HTTP.Assertions {
+		unsupported = append(unsupported, provider.DescribeAssertion(a))
+	}
+
+	return provider.UnsupportedAssertions("Logger", unsupported)
+}
+
 // Create logs out a create action.
-func (p *prov) Create(ts v1alpha1.MonitorTemplateSpec) (string, error) {
+func (p *prov) Create(_ context.Context, ts v1alpha1.MonitorTemplateSpec) (string, error) {
+	if err := validate(ts); err != nil {
+		return "", err
+	}
+
 	logrus.Infof("Creating monitor %s", ts.Name)
 
-	return ts.Name, nil
+	return ts.Name, assertionsError(ts)
 }
 
 // Delete logs out a delete action.
-func (p *prov) Delete(id string) error {
+func (p *prov) Delete(_ context.Context, id string) error {
 	logrus.Infof("Deleting monitor %s", id)
 
 	return nil
 }
 
 // Update logs out the update information for this template spec.
-func (p *prov) Update(id string, ts v1alpha1.MonitorTemplateSpec) (string, error) {
+func (p *prov) Update(_ context.Context, id string, ts v1alpha1.MonitorTemplateSpec) (string, error) {
+	if err := validate(ts); err != nil {
+		return id, err
+	}
+
 	logrus.Infof("Updating monitor %s with ID %s", ts.Name, id)
 
-	return id, nil
+	return id, assertionsError(ts)
+}
+
+// List logs out a list action.
+func (p *prov) List(_ context.Context) ([]provider.Monitor, error) {
+	logrus.Infof("Listing monitors")
+
+	return nil, nil
+}
+
+// Pause logs out a pause action.
+func (p *prov) Pause(_ context.Context, id string) error {
+	logrus.Infof("Pausing monitor %s", id)
+
+	return nil
+}
+
+// Resume logs out a resume action.
+func (p *prov) Resume(_ context.Context, id string) error {
+	logrus.Infof("Resuming monitor %s", id)
+
+	return nil
 }
@@ -1,10 +1,106 @@
 package provider
 
-import "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+)
+
+// DefaultTimeout is the maximum duration of a single call to a provider when
+// the Provider doesn't configure a timeout.
+const DefaultTimeout = 30 * time.Second
+
+// DefaultCertificateThresholds describe how long before a certificate expires
+// alerts are sent when the MonitorTemplate doesn't configure any.
+var DefaultCertificateThresholds = []time.Duration{
+	30 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+}
 
 // Interface reflects interface we'll use to speak with Monitoring Providers.
+// Every call takes a context, which is cancelled when the call takes longer
+// than the timeout of the Provider or when the Operator shuts down.
+// Providers should report failures with the errors defined in this package, so
+// the Operator knows how to react to them.
 type Interface interface {
-	Create(v1alpha1.MonitorTemplateSpec) (string, error)
-	Delete(string) error
-	Update(string, v1alpha1.MonitorTemplateSpec) (string, error)
+	Create(context.Context, v1alpha1.MonitorTemplateSpec) (string, error)
+	Delete(context.Context, string) error
+	Update(context.Context, string, v1alpha1.MonitorTemplateSpec) (string, error)
+
+	// List returns all the monitors which are configured with the provider.
+	// The Operator uses it to adopt monitors it created without recording
+	// their ID.
+	List(context.Context) ([]Monitor, error)
+
+	// Pause stops the monitor with the given ID from running its check,
+	// without removing it or its history. Resume starts it again. Providers
+	// which can't pause a monitor return ErrPauseUnsupported.
+	Pause(context.Context, string) error
+	Resume(context.Context, string) error
+}
+
+// Monitor describes a monitor which is configured with a provider.
+type Monitor struct {
+	ID   string
+	Spec v1alpha1.MonitorTemplateSpec
+}
+
+// Timeout returns the maximum duration of a single call to the given
+// provider.
+func Timeout(prov v1alpha1.ProviderSpec) (time.Duration, error) {
+	if prov.Timeout == nil {
+		return DefaultTimeout, nil
+	}
+
+	tm, err := time.ParseDuration(*prov.Timeout)
+	if err != nil {
+		return DefaultTimeout, err
+	}
+
+	if tm <= 0 {
+		return DefaultTimeout, fmt.Errorf("timeout should be positive, got %s", tm)
+	}
+
+	return tm, nil
+}
+
+// CertificateThresholds returns the warning thresholds of the given
+// certificate check, from the longest to the shortest.
+func CertificateThresholds(cert *v1alpha1.CertificateTemplate) ([]time.Duration, error) {
+	if cert == nil || len(cert.WarningThresholds) == 0 {
+		return DefaultCertificateThresholds, nil
+	}
+
+	thresholds := make([]time.Duration, 0, len(cert.WarningThresholds))
+	for _, val := range cert.WarningThresholds {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return nil, err
+		}
+
+		if d <= 0 {
+			return nil, fmt.Errorf("warning threshold should be positive, got %s", d)
+		}
+
+		thresholds = append(thresholds, d)
+	}
+
+	sort.Slice(thresholds, func(i, j int) bool { return thresholds[i] > thresholds[j] })
+	return thresholds, nil
+}
+
+// SecretValue returns the value of credentials in a MonitorTemplateSpec. The
+// Operator resolves references to Secrets before it passes the spec on to a
+// provider, so providers only have to deal with values.
+func SecretValue(sv v1alpha1.SecretVar) (string, error) {
+	if sv.Value == nil {
+		return "", errors.New("credentials haven't been resolved")
+	}
+
+	return *sv.Value, nil
 }
@@ -0,0 +1,132 @@
+package provider_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+)
+
+func TestTimeout(t *testing.T) {
+	tcs := []struct {
+		name    string
+		timeout *string
+		exp     time.Duration
+		err     bool
+	}{
+		{"without a timeout", nil, provider.DefaultTimeout, false},
+		{"with a timeout", ptrString("5s"), 5 * time.Second, false},
+		{"with an invalid timeout", ptrString("soon"), provider.DefaultTimeout, true},
+		{"with a negative timeout", ptrString("-5s"), provider.DefaultTimeout, true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tm, err := provider.Timeout(v1alpha1.ProviderSpec{Timeout: tc.timeout})
+			if (err != nil) != tc.err {
+				t.Errorf("Expected error to be %t, got %v", tc.err, err)
+			}
+
+			if tm != tc.exp {
+				t.Errorf("Expected timeout to be %s, got %s", tc.exp, tm)
+			}
+		})
+	}
+}
+
+func TestCertificateThresholds(t *testing.T) {
+	tcs := []struct {
+		name       string
+		thresholds []string
+		exp        []time.Duration
+		err        bool
+	}{
+		{"without thresholds", nil, provider.DefaultCertificateThresholds, false},
+		{"with thresholds", []string{"24h", "336h"}, []time.Duration{336 * time.Hour, 24 * time.Hour}, false},
+		{"with an invalid threshold", []string{"soon"}, nil, true},
+		{"with a negative threshold", []string{"-24h"}, nil, true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			thresholds, err := provider.CertificateThresholds(&v1alpha1.CertificateTemplate{WarningThresholds: tc.thresholds})
+			if (err != nil) != tc.err {
+				t.Errorf("Expected error to be %t, got %v", tc.err, err)
+			}
+
+			if !reflect.DeepEqual(tc.exp, thresholds) {
+				t.Errorf("Expected thresholds to be %v, got %v", tc.exp, thresholds)
+			}
+		})
+	}
+}
+
+func ptrString(s string) *string {
+	return &s
+}
+
+func TestSecretValue(t *testing.T) {
+	val, err := provider.SecretValue(v1alpha1.SecretVar{Value: ptrString("hunter2")})
+	if err != nil || val != "hunter2" {
+		t.Errorf("Expected the value to be returned, got %q and %v", val, err)
+	}
+
+	if _, err := provider.SecretValue(v1alpha1.SecretVar{}); err == nil {
+		t.Errorf("Expected an error for unresolved credentials")
+	}
+}
+
+func TestValidateAssertion(t *testing.T) {
+	valid := []v1alpha1.Assertion{
+		{Regex: "^ok$"},
+		{JSONPath: &v1alpha1.JSONPathAssertion{Path: "$.status", Equals: "ok"}},
+		{Header: &v1alpha1.HeaderAssertion{Name: "X-Version"}},
+		{MaxResponseTime: "2s"},
+	}
+	for _, a := range valid {
+		if err := provider.ValidateAssertion(a); err != nil {
+			t.Errorf("Expected %s to be valid, got %s", provider.DescribeAssertion(a), err)
+		}
+	}
+
+	invalid := []v1alpha1.Assertion{
+		{},
+		{Regex: "("},
+		{JSONPath: &v1alpha1.JSONPathAssertion{}},
+		{Header: &v1alpha1.HeaderAssertion{}},
+		{MaxResponseTime: "-1s"},
+		{Regex: "ok", MaxResponseTime: "2s"},
+	}
+	for _, a := range invalid {
+		if err := provider.ValidateAssertion(a); err == nil {
+			t.Errorf("Expected an error for %#v", a)
+		}
+	}
+}
+
+func TestValidateLocations(t *testing.T) {
+	minFailing := func(i int) *int { return &i }
+
+	valid := []*v1alpha1.LocationTemplate{
+		nil,
+		{Regions: []string{"europe", "asia"}},
+		{MinFailing: minFailing(2)},
+	}
+	for _, loc := range valid {
+		if err := provider.ValidateLocations(loc); err != nil {
+			t.Errorf("Expected %#v to be valid, got %s", loc, err)
+		}
+	}
+
+	invalid := []*v1alpha1.LocationTemplate{
+		{Regions: []string{"eu-west-1"}},
+		{MinFailing: minFailing(0)},
+	}
+	for _, loc := range invalid {
+		if err := provider.ValidateLocations(loc); err == nil {
+			t.Errorf("Expected an error for %#v", loc)
+		}
+	}
+}
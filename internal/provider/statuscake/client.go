@@ -0,0 +1,678 @@
+package statuscake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+)
+
+// apiURL is the base URL of the StatusCake v1 API.
+const apiURL = "https://app.statuscake.com/API"
+
+// defaultRetryAfter is used when StatusCake rate limits us without telling us
+// when to retry.
+const defaultRetryAfter = time.Minute
+
+// Test is a StatusCake test as it's known by the v1 API. Fields which are
+// only returned by the API, like the Status, are ignored when updating a
+// test.
+type Test struct {
+	TestID   int
+	TestType string
+	// Paused is only sent when it's set, so updating a test doesn't resume
+	// it when it's paused outside of the operator.
+	Paused         *bool
+	WebsiteName    string
+	WebsiteURL     string
+	WebsiteHost    string
+	Port           int
+	NodeLocations  []string
+	ContactGroup   []string
+	CheckRate      int
+	Timeout        int
+	Confirmation   int
+	TriggerRate    int
+	CustomHeader   string
+	UserAgent      string
+	FindString     string
+	DoNotFind      bool
+	FollowRedirect bool
+	EnableSSLAlert bool
+	StatusCodes    []string
+	BasicUser      string
+	BasicPass      string
+	PostRaw        string
+	DNSServer      string
+	DNSIP          string
+	TestTags       []string
+
+	Status string
+	Uptime float64
+}
+
+// values encodes the test as the form the Update endpoint expects.
+func (t *Test) values() url.Values {
+	v := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			v.Set(key, value)
+		}
+	}
+	setInt := func(key string, value int) {
+		if value != 0 {
+			v.Set(key, strconv.Itoa(value))
+		}
+	}
+	setBool := func(key string, value bool) {
+		if value {
+			v.Set(key, "1")
+		} else {
+			v.Set(key, "0")
+		}
+	}
+
+	setInt("TestID", t.TestID)
+	set("TestType", t.TestType)
+	if t.Paused != nil {
+		setBool("Paused", *t.Paused)
+	}
+	set("WebsiteName", t.WebsiteName)
+	set("WebsiteURL", t.WebsiteURL)
+	set("WebsiteHost", t.WebsiteHost)
+	setInt("Port", t.Port)
+	set("NodeLocations", strings.Join(t.NodeLocations, ","))
+	// An empty list of contact groups removes all of them from the test.
+	v.Set("ContactGroup", strings.Join(t.ContactGroup, ","))
+	setInt("CheckRate", t.CheckRate)
+	setInt("Timeout", t.Timeout)
+	setInt("Confirmation", t.Confirmation)
+	setInt("TriggerRate", t.TriggerRate)
+	set("CustomHeader", t.CustomHeader)
+	set("UserAgent", t.UserAgent)
+	set("FindString", t.FindString)
+	setBool("DoNotFind", t.DoNotFind)
+	setBool("FollowRedirect", t.FollowRedirect)
+	setBool("EnableSSLAlert", t.EnableSSLAlert)
+	set("StatusCodes", strings.Join(t.StatusCodes, ","))
+	set("BasicUser", t.BasicUser)
+	set("BasicPass", t.BasicPass)
+	set("PostRaw", t.PostRaw)
+	set("DNSServer", t.DNSServer)
+	set("DNSIP", t.DNSIP)
+	set("TestTags", strings.Join(t.TestTags, ","))
+
+	return v
+}
+
+// apiTest is a test as it's returned by the API. The list and details
+// endpoints use different names for some fields and numbers are sometimes
+// sent as strings.
+type apiTest struct {
+	TestID        apiInt   `json:"TestID"`
+	TestType      string   `json:"TestType"`
+	Paused        bool     `json:"Paused"`
+	WebsiteName   string   `json:"WebsiteName"`
+	WebsiteURL    string   `json:"WebsiteURL"`
+	URI           string   `json:"URI"`
+	WebsiteHost   string   `json:"WebsiteHost"`
+	Port          apiInt   `json:"Port"`
+	NodeLocations []string `json:"NodeLocations"`
+	ContactGroup  []string `json:"ContactGroup"`
+	ContactGroups []struct {
+		ID apiInt `json:"ID"`
+	} `json:"ContactGroups"`
+	CheckRate        apiInt   `json:"CheckRate"`
+	Timeout          apiInt   `json:"Timeout"`
+	Confirmation     apiInt   `json:"Confirmation"`
+	TriggerRate      apiInt   `json:"TriggerRate"`
+	CustomHeader     string   `json:"CustomHeader"`
+	UserAgent        string   `json:"UserAgent"`
+	FindString       string   `json:"FindString"`
+	DoNotFind        bool     `json:"DoNotFind"`
+	FollowRedirect   bool     `json:"FollowRedirect"`
+	EnableSSLWarning bool     `json:"EnableSSLWarning"`
+	StatusCodes      []string `json:"StatusCodes"`
+	BasicUser        string   `json:"BasicUser"`
+	PostRaw          string   `json:"PostRaw"`
+	DNSServer        string   `json:"DNSServer"`
+	DNSIP            string   `json:"DNSIP"`
+	TestTags         []string `json:"TestTags"`
+	Status           string   `json:"Status"`
+	Uptime           float64  `json:"Uptime"`
+}
+
+func (t *apiTest) test() *Test {
+	paused := t.Paused
+	test := &Test{
+		TestID:         int(t.TestID),
+		TestType:       t.TestType,
+		Paused:         &paused,
+		WebsiteName:    t.WebsiteName,
+		WebsiteURL:     t.WebsiteURL,
+		WebsiteHost:    t.WebsiteHost,
+		Port:           int(t.Port),
+		NodeLocations:  t.NodeLocations,
+		ContactGroup:   t.ContactGroup,
+		CheckRate:      int(t.CheckRate),
+		Timeout:        int(t.Timeout),
+		Confirmation:   int(t.Confirmation),
+		TriggerRate:    int(t.TriggerRate),
+		CustomHeader:   t.CustomHeader,
+		UserAgent:      t.UserAgent,
+		FindString:     t.FindString,
+		DoNotFind:      t.DoNotFind,
+		FollowRedirect: t.FollowRedirect,
+		EnableSSLAlert: t.EnableSSLWarning,
+		StatusCodes:    t.StatusCodes,
+		BasicUser:      t.BasicUser,
+		PostRaw:        t.PostRaw,
+		DNSServer:      t.DNSServer,
+		DNSIP:          t.DNSIP,
+		TestTags:       t.TestTags,
+		Status:         t.Status,
+		Uptime:         t.Uptime,
+	}
+
+	if test.WebsiteURL == "" {
+		test.WebsiteURL = t.URI
+	}
+
+	// The details endpoint returns the contact groups as objects, next to
+	// the list of IDs the tests endpoint returns.
+	if len(t.ContactGroups) > 0 {
+		test.ContactGroup = make([]string, 0, len(t.ContactGroups))
+		for _, group := range t.ContactGroups {
+			test.ContactGroup = append(test.ContactGroup, strconv.Itoa(int(group.ID)))
+		}
+	}
+
+	return test
+}
+
+// apiInt is a number which the API sends either as a number or as a string.
+type apiInt int
+
+func (i *apiInt) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*i = 0
+		return nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("invalid number %s", data)
+	}
+
+	*i = apiInt(n)
+	return nil
+}
+
+// apiString is a string which the API sometimes sends as a number, like the
+// ID of a new SSL check.
+type apiString string
+
+func (s *apiString) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*s = apiString(str)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("invalid string %s", data)
+	}
+
+	*s = apiString(n.String())
+	return nil
+}
+
+// apiResponse describes the fields the API uses to report the outcome of a
+// call.
+type apiResponse struct {
+	Success  *bool           `json:"Success"`
+	Message  apiString       `json:"Message"`
+	Error    string          `json:"Error"`
+	ErrNo    *int            `json:"ErrNo"`
+	Issues   json.RawMessage `json:"Issues"`
+	InsertID apiInt          `json:"InsertID"`
+}
+
+func (r *apiResponse) message() string {
+	if r.Message != "" {
+		return string(r.Message)
+	}
+
+	return r.Error
+}
+
+func (r *apiResponse) hasIssues() bool {
+	switch strings.TrimSpace(string(r.Issues)) {
+	case "", "null", "[]", "{}":
+		return false
+	}
+
+	return true
+}
+
+// APIError is returned when the StatusCake API responds with an error. It
+// wraps the matching error of the provider package, so the Operator knows how
+// to react to it.
+type APIError struct {
+	StatusCode int
+	Message    string
+
+	err error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("StatusCake API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// Unwrap returns the provider error which matches the API error, if any.
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+// httpError translates an HTTP error response into an APIError.
+func httpError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+
+	var r apiResponse
+	if json.Unmarshal(body, &r) == nil && r.message() != "" {
+		apiErr.Message = r.message()
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		apiErr.err = provider.ErrUnauthorized
+	case http.StatusNotFound:
+		apiErr.err = provider.ErrNotFound
+	case http.StatusTooManyRequests:
+		apiErr.err = &provider.RateLimitError{RetryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		apiErr.err = provider.ErrInvalidSpec
+	}
+
+	return apiErr
+}
+
+// responseError checks the body of a successful HTTP response for errors. The
+// v1 API reports most errors with a 200 status code, so we have to go by the
+// body. Calls which are refused without any issues aren't classified here,
+// the API uses the same response for different problems. See refused.
+func responseError(statusCode int, r *apiResponse) *APIError {
+	switch {
+	case r.ErrNo != nil:
+		// ErrNo is only set when the API can't authenticate the request.
+		return &APIError{StatusCode: statusCode, Message: r.message(), err: provider.ErrUnauthorized}
+	case r.Success == nil || *r.Success:
+		return nil
+	}
+
+	apiErr := &APIError{StatusCode: statusCode, Message: r.message()}
+	if r.hasIssues() {
+		apiErr.Message = fmt.Sprintf("%s: %s", apiErr.Message, r.Issues)
+		apiErr.err = provider.ErrInvalidSpec
+	}
+
+	return apiErr
+}
+
+// refused reports if the API refused a call without telling us why. The v1
+// API does this both for tests and SSL checks which don't exist and for
+// updates which don't change anything, only its message tells them apart.
+// Instead of relying on the wording, callers look at the account to find out
+// what happened.
+func refused(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.err == nil &&
+		apiErr.StatusCode >= 200 && apiErr.StatusCode <= 299
+}
+
+// classify marks the given refusal with the given provider error.
+func classify(err, perr error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	return &APIError{StatusCode: apiErr.StatusCode, Message: apiErr.Message, err: perr}
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or a date.
+func retryAfter(header string) time.Duration {
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+		return 0
+	}
+
+	return defaultRetryAfter
+}
+
+// SSLCheck is a StatusCake SSL check as it's known by the v1 API. SSL checks
+// are managed separately from tests. Fields which are only returned by the
+// API, like ValidUntil, are ignored when updating a check.
+type SSLCheck struct {
+	ID            int
+	Domain        string
+	CheckRate     int
+	ContactGroups []string
+	// AlertAt are the number of days before the certificate expires at which
+	// alerts are sent. StatusCake expects exactly three values.
+	AlertAt       []int
+	AlertExpiry   bool
+	AlertReminder bool
+	AlertBroken   bool
+	AlertMixed    bool
+
+	Paused     bool
+	ValidUntil string
+}
+
+// values encodes the SSL check as the form the SSL Update endpoint expects.
+func (c *SSLCheck) values() url.Values {
+	v := url.Values{}
+	boolValue := func(b bool) string {
+		if b {
+			return "true"
+		}
+		return "false"
+	}
+
+	if c.ID != 0 {
+		v.Set("id", strconv.Itoa(c.ID))
+	}
+
+	alertAt := make([]string, 0, len(c.AlertAt))
+	for _, days := range c.AlertAt {
+		alertAt = append(alertAt, strconv.Itoa(days))
+	}
+
+	v.Set("domain", c.Domain)
+	v.Set("checkrate", strconv.Itoa(c.CheckRate))
+	v.Set("contact_groups", strings.Join(c.ContactGroups, ","))
+	v.Set("alert_at", strings.Join(alertAt, ","))
+	v.Set("alert_expiry", boolValue(c.AlertExpiry))
+	v.Set("alert_reminder", boolValue(c.AlertReminder))
+	v.Set("alert_broken", boolValue(c.AlertBroken))
+	v.Set("alert_mixed", boolValue(c.AlertMixed))
+
+	return v
+}
+
+// apiSSLCheck is an SSL check as it's returned by the API.
+type apiSSLCheck struct {
+	ID            apiInt   `json:"id"`
+	Domain        string   `json:"domain"`
+	CheckRate     apiInt   `json:"checkrate"`
+	ContactGroups []string `json:"contact_groups"`
+	AlertAt       string   `json:"alert_at"`
+	AlertExpiry   bool     `json:"alert_expiry"`
+	AlertReminder bool     `json:"alert_reminder"`
+	AlertBroken   bool     `json:"alert_broken"`
+	AlertMixed    bool     `json:"alert_mixed"`
+	Paused        bool     `json:"paused"`
+	ValidUntil    string   `json:"valid_until_utc"`
+}
+
+func (c *apiSSLCheck) check() *SSLCheck {
+	check := &SSLCheck{
+		ID:            int(c.ID),
+		Domain:        c.Domain,
+		CheckRate:     int(c.CheckRate),
+		ContactGroups: c.ContactGroups,
+		AlertExpiry:   c.AlertExpiry,
+		AlertReminder: c.AlertReminder,
+		AlertBroken:   c.AlertBroken,
+		AlertMixed:    c.AlertMixed,
+		Paused:        c.Paused,
+		ValidUntil:    c.ValidUntil,
+	}
+
+	for _, days := range strings.Split(c.AlertAt, ",") {
+		if i, err := strconv.Atoi(strings.TrimSpace(days)); err == nil {
+			check.AlertAt = append(check.AlertAt, i)
+		}
+	}
+
+	return check
+}
+
+// apiClient talks to the StatusCake v1 API.
+type apiClient struct {
+	baseURL  string
+	username string
+	apiKey   string
+	http     *http.Client
+}
+
+func newAPIClient(username, apiKey string) *apiClient {
+	return &apiClient{
+		baseURL:  apiURL,
+		username: username,
+		apiKey:   apiKey,
+		http:     &http.Client{},
+	}
+}
+
+// Tests lists all the tests in the StatusCake account.
+func (c *apiClient) Tests(ctx context.Context) ([]*Test, error) {
+	var apiTests []apiTest
+	if err := c.do(ctx, http.MethodGet, "/Tests/", nil, &apiTests); err != nil {
+		return nil, err
+	}
+
+	tests := make([]*Test, 0, len(apiTests))
+	for i := range apiTests {
+		tests = append(tests, apiTests[i].test())
+	}
+
+	return tests, nil
+}
+
+// Detail fetches all the details of the test with the given ID.
+func (c *apiClient) Detail(ctx context.Context, id int) (*Test, error) {
+	var t apiTest
+	if err := c.do(ctx, http.MethodGet, "/Tests/Details/?TestID="+strconv.Itoa(id), nil, &t); err != nil {
+		return nil, c.confirmTestError(ctx, id, err)
+	}
+
+	return t.test(), nil
+}
+
+// Update creates the given test when it doesn't have an ID yet, or updates
+// the existing test otherwise. The ID of a new test is returned; for existing
+// tests, 0 is returned. Updates which don't change the test return
+// provider.ErrNoChange.
+func (c *apiClient) Update(ctx context.Context, t *Test) (int, error) {
+	var r apiResponse
+	err := c.do(ctx, http.MethodPut, "/Tests/Update", t.values(), &r)
+	if err == nil {
+		return int(r.InsertID), nil
+	} else if t.TestID == 0 || !refused(err) {
+		return 0, err
+	}
+
+	// The API refuses updates which wouldn't change anything, and updates of
+	// tests which don't exist. Comparing the test with the one we sent doesn't
+	// tell us anything, the API fills in defaults for the fields we leave out,
+	// so we only check if the test still exists.
+	if _, derr := c.Detail(ctx, t.TestID); derr != nil {
+		return 0, derr
+	}
+
+	return 0, classify(err, provider.ErrNoChange)
+}
+
+// Delete deletes the test with the given ID.
+func (c *apiClient) Delete(ctx context.Context, id int) error {
+	err := c.do(ctx, http.MethodDelete, "/Tests/Details/?TestID="+strconv.Itoa(id), nil, nil)
+	return c.confirmTestError(ctx, id, err)
+}
+
+// confirmTestError checks if the test with the given ID still exists when the
+// API refused a call for it. When it's not in the list of tests anymore, the
+// refusal is reported as not found.
+func (c *apiClient) confirmTestError(ctx context.Context, id int, err error) error {
+	if !refused(err) {
+		return err
+	}
+
+	tests, lerr := c.Tests(ctx)
+	if lerr != nil {
+		return err
+	}
+
+	for _, t := range tests {
+		if t.TestID == id {
+			return err
+		}
+	}
+
+	return classify(err, provider.ErrNotFound)
+}
+
+// SSLChecks lists all the SSL checks in the StatusCake account.
+func (c *apiClient) SSLChecks(ctx context.Context) ([]*SSLCheck, error) {
+	var apiChecks []apiSSLCheck
+	if err := c.do(ctx, http.MethodGet, "/SSL/", nil, &apiChecks); err != nil {
+		return nil, err
+	}
+
+	checks := make([]*SSLCheck, 0, len(apiChecks))
+	for i := range apiChecks {
+		checks = append(checks, apiChecks[i].check())
+	}
+
+	return checks, nil
+}
+
+// UpdateSSL creates the given SSL check when it doesn't have an ID yet, or
+// updates the existing check otherwise. The ID of a new check is returned;
+// for existing checks, 0 is returned.
+func (c *apiClient) UpdateSSL(ctx context.Context, check *SSLCheck) (int, error) {
+	var r apiResponse
+	if err := c.do(ctx, http.MethodPut, "/SSL/Update", check.values(), &r); err != nil {
+		return 0, c.confirmSSLError(ctx, check.ID, err)
+	}
+
+	if check.ID != 0 {
+		return 0, nil
+	}
+
+	// New checks return their ID as the message.
+	id, err := strconv.Atoi(string(r.Message))
+	if err != nil {
+		return 0, fmt.Errorf("could not read the ID of the new SSL check: %s", r.Message)
+	}
+
+	return id, nil
+}
+
+// DeleteSSL deletes the SSL check with the given ID.
+func (c *apiClient) DeleteSSL(ctx context.Context, id int) error {
+	err := c.do(ctx, http.MethodDelete, "/SSL/Update?id="+strconv.Itoa(id), nil, nil)
+	return c.confirmSSLError(ctx, id, err)
+}
+
+// confirmSSLError checks if the SSL check with the given ID still exists when
+// the API refused a call for it. When it's not in the list of SSL checks
+// anymore, the refusal is reported as not found.
+func (c *apiClient) confirmSSLError(ctx context.Context, id int, err error) error {
+	if id == 0 || !refused(err) {
+		return err
+	}
+
+	checks, lerr := c.SSLChecks(ctx)
+	if lerr != nil {
+		return err
+	}
+
+	for _, check := range checks {
+		if check.ID == id {
+			return err
+		}
+	}
+
+	return classify(err, provider.ErrNotFound)
+}
+
+// do performs a request against the API and decodes the response into the
+// given value. Errors reported by the API are returned as an APIError.
+func (c *apiClient) do(ctx context.Context, method, path string, form url.Values, into interface{}) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Username", c.username)
+	req.Header.Set("API", c.apiKey)
+	req.Header.Set("User-Agent", "ingress-monitor")
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not read the StatusCake response: %s", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return httpError(resp, data)
+	}
+
+	// Lists are returned as an array, while errors are always returned as
+	// an object.
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var r apiResponse
+		if err := json.Unmarshal(data, &r); err != nil {
+			return fmt.Errorf("could not decode the StatusCake response: %s", err)
+		}
+
+		if apiErr := responseError(resp.StatusCode, &r); apiErr != nil {
+			return apiErr
+		}
+	}
+
+	if into == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, into); err != nil {
+		return fmt.Errorf("could not decode the StatusCake response: %s", err)
+	}
+
+	return nil
+}
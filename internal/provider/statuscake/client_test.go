@@ -0,0 +1,610 @@
+package statuscake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+)
+
+func TestAPIClient_Tests(t *testing.T) {
+	api := newFakeAPI()
+	api.tests[12345] = `{"TestID":12345,"Paused":false,"TestType":"HTTP","WebsiteName":"first","WebsiteURL":"https://example.com","ContactGroup":["678"],"Status":"Up","Uptime":99.5,"CheckRate":300,"Timeout":"40","NodeLocations":["EU1","US1"]}`
+	cl, done := api.client()
+	defer done()
+
+	tests, err := cl.Tests(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	exp := []*Test{
+		{
+			TestID:        12345,
+			TestType:      "HTTP",
+			Paused:        ptrBool(false),
+			WebsiteName:   "first",
+			WebsiteURL:    "https://example.com",
+			ContactGroup:  []string{"678"},
+			Status:        "Up",
+			Uptime:        99.5,
+			CheckRate:     300,
+			Timeout:       40,
+			NodeLocations: []string{"EU1", "US1"},
+		},
+	}
+	if !reflect.DeepEqual(exp, tests) {
+		t.Errorf("Expected tests to equal \n%#v\ngot\n%#v", exp[0], tests[0])
+	}
+}
+
+func TestAPIClient_Detail(t *testing.T) {
+	api := newFakeAPI()
+	api.tests[12345] = `{"TestID":12345,"TestType":"HTTP","Paused":true,"WebsiteName":"first","URI":"https://example.com","ContactGroup":["678"],"ContactGroups":[{"ID":678,"Name":"ops"}],"CheckRate":300,"Timeout":40,"Confirmation":"2","FindString":"OK","DoNotFind":false,"FollowRedirect":true,"EnableSSLWarning":true,"StatusCodes":["500","502"],"BasicUser":"user","TestTags":["prod"]}`
+	cl, done := api.client()
+	defer done()
+
+	t.Run("existing test", func(t *testing.T) {
+		test, err := cl.Detail(context.Background(), 12345)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		exp := &Test{
+			TestID:         12345,
+			TestType:       "HTTP",
+			Paused:         ptrBool(true),
+			WebsiteName:    "first",
+			WebsiteURL:     "https://example.com",
+			ContactGroup:   []string{"678"},
+			CheckRate:      300,
+			Timeout:        40,
+			Confirmation:   2,
+			FindString:     "OK",
+			FollowRedirect: true,
+			EnableSSLAlert: true,
+			StatusCodes:    []string{"500", "502"},
+			BasicUser:      "user",
+			TestTags:       []string{"prod"},
+		}
+		if !reflect.DeepEqual(exp, test) {
+			t.Errorf("Expected test to equal \n%#v\ngot\n%#v", exp, test)
+		}
+	})
+
+	t.Run("unknown test", func(t *testing.T) {
+		if _, err := cl.Detail(context.Background(), 67890); !errors.Is(err, provider.ErrNotFound) {
+			t.Errorf("Expected `%s` error, got `%v`", provider.ErrNotFound, err)
+		}
+	})
+}
+
+func TestAPIClient_Update(t *testing.T) {
+	api := newFakeAPI()
+	cl, done := api.client()
+	defer done()
+
+	test := &Test{
+		TestType:     "HTTP",
+		WebsiteName:  "first",
+		WebsiteURL:   "https://example.com",
+		CheckRate:    300,
+		StatusCodes:  []string{"500", "502"},
+		ContactGroup: []string{"678", "910"},
+	}
+
+	t.Run("new test", func(t *testing.T) {
+		id, err := cl.Update(context.Background(), test)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if id != 1 {
+			t.Errorf("Expected ID to be 1, got %d", id)
+		}
+
+		form := api.form()
+		for key, exp := range map[string]string{
+			"WebsiteName":  "first",
+			"WebsiteURL":   "https://example.com",
+			"CheckRate":    "300",
+			"StatusCodes":  "500,502",
+			"ContactGroup": "678,910",
+		} {
+			if val := form.Get(key); val != exp {
+				t.Errorf("Expected %s to be `%s`, got `%s`", key, exp, val)
+			}
+		}
+
+		for _, key := range []string{"TestID", "Paused"} {
+			if _, ok := form[key]; ok {
+				t.Errorf("Expected no %s to be sent for a new test", key)
+			}
+		}
+	})
+
+	t.Run("existing test", func(t *testing.T) {
+		test.TestID = 1
+		test.CheckRate = 60
+		id, err := cl.Update(context.Background(), test)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if id != 0 {
+			t.Errorf("Expected no ID for an update, got %d", id)
+		}
+	})
+
+	t.Run("without changes", func(t *testing.T) {
+		id, err := cl.Update(context.Background(), test)
+		if !errors.Is(err, provider.ErrNoChange) {
+			t.Errorf("Expected `%s` error, got `%v`", provider.ErrNoChange, err)
+		}
+
+		if id != 0 {
+			t.Errorf("Expected no ID for an update, got %d", id)
+		}
+	})
+
+	t.Run("paused test", func(t *testing.T) {
+		paused := *test
+		paused.Paused = ptrBool(true)
+		if _, err := cl.Update(context.Background(), &paused); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		test.CheckRate = 30
+		if _, err := cl.Update(context.Background(), test); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		detail, err := cl.Detail(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if detail.Paused == nil || !*detail.Paused {
+			t.Errorf("Expected the test to stay paused")
+		}
+	})
+
+	t.Run("unknown test", func(t *testing.T) {
+		unknown := *test
+		unknown.TestID = 67890
+		if _, err := cl.Update(context.Background(), &unknown); !errors.Is(err, provider.ErrNotFound) {
+			t.Errorf("Expected `%s` error, got `%v`", provider.ErrNotFound, err)
+		}
+	})
+
+	t.Run("with issues", func(t *testing.T) {
+		invalid := &Test{TestType: "HTTP", WebsiteName: "invalid"}
+		if _, err := cl.Update(context.Background(), invalid); !errors.Is(err, provider.ErrInvalidSpec) {
+			t.Errorf("Expected `%s` error, got `%v`", provider.ErrInvalidSpec, err)
+		}
+	})
+}
+
+func TestAPIClient_Delete(t *testing.T) {
+	api := newFakeAPI()
+	api.tests[12345] = `{"TestID":12345}`
+	cl, done := api.client()
+	defer done()
+
+	if err := cl.Delete(context.Background(), 12345); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	if api.has(12345) {
+		t.Errorf("Expected the test to be deleted")
+	}
+
+	if err := cl.Delete(context.Background(), 12345); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("Expected `%s` error, got `%v`", provider.ErrNotFound, err)
+	}
+}
+
+func TestAPIClient_SSL(t *testing.T) {
+	api := newFakeAPI()
+	cl, done := api.client()
+	defer done()
+
+	check := &SSLCheck{
+		Domain:        "https://api.example.com",
+		CheckRate:     86400,
+		ContactGroups: []string{"678"},
+		AlertAt:       []int{1, 7, 30},
+		AlertExpiry:   true,
+	}
+
+	id, err := cl.UpdateSSL(context.Background(), check)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if id != 1 {
+		t.Errorf("Expected ID to be 1, got %d", id)
+	}
+
+	form := api.form()
+	for key, exp := range map[string]string{
+		"domain":         "https://api.example.com",
+		"checkrate":      "86400",
+		"contact_groups": "678",
+		"alert_at":       "1,7,30",
+		"alert_expiry":   "true",
+		"alert_broken":   "false",
+	} {
+		if val := form.Get(key); val != exp {
+			t.Errorf("Expected %s to be `%s`, got `%s`", key, exp, val)
+		}
+	}
+
+	checks, err := cl.SSLChecks(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	exp := []*SSLCheck{
+		{ID: 1, Domain: "https://api.example.com", CheckRate: 86400, AlertAt: []int{1, 7, 30}},
+	}
+	if !reflect.DeepEqual(exp, checks) {
+		t.Errorf("Expected SSL checks to equal \n%#v\ngot\n%#v", exp, checks)
+	}
+
+	check.ID = 1
+	if id, err := cl.UpdateSSL(context.Background(), check); err != nil || id != 0 {
+		t.Errorf("Expected no ID and no error for an update, got %d and %v", id, err)
+	}
+
+	if err := cl.DeleteSSL(context.Background(), 1); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	if err := cl.DeleteSSL(context.Background(), 1); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("Expected `%s` error, got `%v`", provider.ErrNotFound, err)
+	}
+}
+
+func TestAPIClient_Errors(t *testing.T) {
+	tcs := []struct {
+		name   string
+		status int
+		header http.Header
+		body   string
+		exp    error
+	}{
+		{"unauthorized", http.StatusUnauthorized, nil, `{"Message":"Unauthorized"}`, provider.ErrUnauthorized},
+		{"forbidden", http.StatusForbidden, nil, "", provider.ErrUnauthorized},
+		{"authentication failure in the body", http.StatusOK, nil, `{"ErrNo":0,"Error":"API Authentication Failed"}`, provider.ErrUnauthorized},
+		{"not found", http.StatusNotFound, nil, "", provider.ErrNotFound},
+		{"rate limited", http.StatusTooManyRequests, nil, "", provider.ErrRateLimited},
+		{"bad request", http.StatusBadRequest, nil, `{"Message":"Invalid TestType"}`, provider.ErrInvalidSpec},
+		{"unprocessable", http.StatusUnprocessableEntity, nil, "", provider.ErrInvalidSpec},
+		{"server error", http.StatusBadGateway, nil, "", nil},
+		// Errors are classified by their status code and structured fields,
+		// never by the wording of the message.
+		{"refusal mentioning a rate limit", http.StatusOK, nil, `{"Success":false,"Message":"API rate limit reached, authentication failed"}`, nil},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			api := newFakeAPI()
+			api.fail(tc.status, tc.header, tc.body)
+			cl, done := api.client()
+			defer done()
+
+			_, err := cl.Detail(context.Background(), 12345)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected an APIError, got `%v`", err)
+			}
+
+			if apiErr.StatusCode != tc.status {
+				t.Errorf("Expected status code %d, got %d", tc.status, apiErr.StatusCode)
+			}
+
+			if tc.exp == nil {
+				if apiErr.Unwrap() != nil {
+					t.Errorf("Expected no provider error, got `%s`", apiErr.Unwrap())
+				}
+				return
+			}
+
+			if !errors.Is(err, tc.exp) {
+				t.Errorf("Expected `%s` error, got `%v`", tc.exp, err)
+			}
+		})
+	}
+
+	t.Run("rate limited with a retry after", func(t *testing.T) {
+		for header, exp := range map[string]time.Duration{
+			"":        defaultRetryAfter,
+			"120":     2 * time.Minute,
+			"invalid": defaultRetryAfter,
+		} {
+			api := newFakeAPI()
+			api.fail(http.StatusTooManyRequests, http.Header{"Retry-After": []string{header}}, "")
+			cl, done := api.client()
+
+			var rl *provider.RateLimitError
+			_, err := cl.Detail(context.Background(), 12345)
+			if !errors.As(err, &rl) {
+				t.Fatalf("Expected a RateLimitError, got `%v`", err)
+			}
+
+			if rl.RetryAfter != exp {
+				t.Errorf("Expected to retry after %s for `%s`, got %s", exp, header, rl.RetryAfter)
+			}
+
+			done()
+		}
+	})
+
+	t.Run("with an expired context", func(t *testing.T) {
+		api := newFakeAPI()
+		api.delay = time.Second
+		cl, done := api.client()
+		defer done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if _, err := cl.Tests(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected `%s` error, got `%v`", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+// fakeAPI is a minimal in-memory implementation of the StatusCake v1 API.
+type fakeAPI struct {
+	mu       sync.Mutex
+	tests    map[int]string
+	ssl      map[int]string
+	nextID   int
+	lastForm url.Values
+	delay    time.Duration
+
+	failStatus int
+	failHeader http.Header
+	failBody   string
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{tests: map[int]string{}, ssl: map[int]string{}, nextID: 1}
+}
+
+func (a *fakeAPI) form() url.Values {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lastForm
+}
+
+func (a *fakeAPI) has(id int) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.tests[id]
+	return ok
+}
+
+func (a *fakeAPI) fail(status int, header http.Header, body string) {
+	a.failStatus = status
+	a.failHeader = header
+	a.failBody = body
+}
+
+// client starts the fake API and returns a client which talks to it. The
+// returned function stops the fake API.
+func (a *fakeAPI) client() (*apiClient, func()) {
+	srv := httptest.NewServer(a)
+	cl := newAPIClient("my-username", "my-api-key")
+	cl.baseURL = srv.URL
+	return cl, srv.Close
+}
+
+func (a *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if a.delay != 0 {
+		select {
+		case <-time.After(a.delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.failStatus != 0 {
+		for key, vals := range a.failHeader {
+			for _, val := range vals {
+				w.Header().Add(key, val)
+			}
+		}
+		w.WriteHeader(a.failStatus)
+		fmt.Fprint(w, a.failBody)
+		return
+	}
+
+	if r.Header.Get("Username") != "my-username" || r.Header.Get("API") != "my-api-key" {
+		fmt.Fprint(w, `{"ErrNo":0,"Error":"API Authentication Failed"}`)
+		return
+	}
+
+	id, _ := strconv.Atoi(r.URL.Query().Get("TestID"))
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/Tests/":
+		tests := make([]string, 0, len(a.tests))
+		for _, test := range a.tests {
+			tests = append(tests, test)
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(tests, ","))
+	case r.Method == http.MethodGet && r.URL.Path == "/Tests/Details/":
+		test, ok := a.tests[id]
+		if !ok {
+			fmt.Fprintf(w, `{"Success":false,"Message":"No matching key can be found on this account. Given: %d"}`, id)
+			return
+		}
+		fmt.Fprint(w, test)
+	case r.Method == http.MethodDelete && r.URL.Path == "/Tests/Details/":
+		if _, ok := a.tests[id]; !ok {
+			fmt.Fprintf(w, `{"Success":false,"Message":"No matching key can be found on this account. Given: %d"}`, id)
+			return
+		}
+		delete(a.tests, id)
+		fmt.Fprintf(w, `{"TestID":%d,"Affected":1,"Success":true,"Message":"This Check Has Been Deleted. It can not be recovered."}`, id)
+	case r.Method == http.MethodPut && r.URL.Path == "/Tests/Update":
+		a.update(w, r)
+	case r.Method == http.MethodGet && r.URL.Path == "/SSL/":
+		checks := make([]string, 0, len(a.ssl))
+		for _, check := range a.ssl {
+			checks = append(checks, check)
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(checks, ","))
+	case r.URL.Path == "/SSL/Update":
+		a.updateSSL(w, r)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (a *fakeAPI) update(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	a.lastForm = r.PostForm
+
+	if r.PostForm.Get("WebsiteURL") == "" {
+		fmt.Fprint(w, `{"Success":false,"Message":"Required Data is Missing.","Issues":{"WebsiteURL":"Required"}}`)
+		return
+	}
+
+	id, _ := strconv.Atoi(r.PostForm.Get("TestID"))
+	if id == 0 {
+		id = a.nextID
+		a.nextID++
+		a.tests[id] = testDetail(id, r.PostForm)
+		fmt.Fprintf(w, `{"Success":true,"Message":"Test Inserted","Issues":{},"InsertID":%d}`, id)
+		return
+	}
+
+	existing, ok := a.tests[id]
+	if _, set := r.PostForm["Paused"]; ok && !set {
+		// Tests stay paused when Paused isn't sent.
+		var detail struct{ Paused bool }
+		json.Unmarshal([]byte(existing), &detail)
+		if detail.Paused {
+			r.PostForm.Set("Paused", "1")
+		}
+	}
+
+	test := testDetail(id, r.PostForm)
+	switch {
+	case !ok:
+		fmt.Fprintf(w, `{"Success":false,"Message":"No matching key can be found on this account. Given: %d"}`, id)
+	case existing == test:
+		fmt.Fprintf(w, `{"Success":false,"Message":"No data has been updated (is any data different?) Given: %d","Issues":[]}`, id)
+	default:
+		a.tests[id] = test
+		fmt.Fprint(w, `{"Success":true,"Message":"Test Updated","Issues":{},"InsertID":0}`)
+	}
+}
+
+// testDetail encodes the form of an update like the details endpoint returns
+// the test. Like the API, it fills in defaults for the settings which aren't
+// sent.
+func testDetail(id int, form url.Values) string {
+	list := func(key string, def ...string) []string {
+		if val := form.Get(key); val != "" {
+			return strings.Split(val, ",")
+		}
+		return def
+	}
+	number := func(key string, def int) int {
+		if n, _ := strconv.Atoi(form.Get(key)); n != 0 {
+			return n
+		}
+		return def
+	}
+	str := func(key, def string) string {
+		if val := form.Get(key); val != "" {
+			return val
+		}
+		return def
+	}
+	flag := func(key string) bool {
+		return form.Get(key) == "1"
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"TestID":           id,
+		"TestType":         form.Get("TestType"),
+		"Paused":           flag("Paused"),
+		"WebsiteName":      form.Get("WebsiteName"),
+		"WebsiteURL":       form.Get("WebsiteURL"),
+		"WebsiteHost":      str("WebsiteHost", "Amazon Web Services"),
+		"Port":             number("Port", 0),
+		"NodeLocations":    list("NodeLocations", "EU1", "US1"),
+		"ContactGroup":     list("ContactGroup"),
+		"CheckRate":        number("CheckRate", 300),
+		"Timeout":          number("Timeout", 40),
+		"Confirmation":     number("Confirmation", 0),
+		"TriggerRate":      number("TriggerRate", 5),
+		"CustomHeader":     form.Get("CustomHeader"),
+		"UserAgent":        form.Get("UserAgent"),
+		"FindString":       form.Get("FindString"),
+		"DoNotFind":        flag("DoNotFind"),
+		"FollowRedirect":   flag("FollowRedirect"),
+		"EnableSSLWarning": flag("EnableSSLAlert"),
+		"StatusCodes":      list("StatusCodes"),
+		"BasicUser":        form.Get("BasicUser"),
+		"PostRaw":          form.Get("PostRaw"),
+		"DNSServer":        form.Get("DNSServer"),
+		"DNSIP":            form.Get("DNSIP"),
+		"TestTags":         list("TestTags"),
+	})
+	return string(data)
+}
+
+func (a *fakeAPI) updateSSL(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	a.lastForm = r.PostForm
+
+	id, _ := strconv.Atoi(r.Form.Get("id"))
+	if _, ok := a.ssl[id]; id != 0 && !ok {
+		fmt.Fprint(w, `{"Success":false,"Message":"No matching key can be found on this account."}`)
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		delete(a.ssl, id)
+		fmt.Fprint(w, `{"Success":true,"Message":"Deletion successful"}`)
+		return
+	}
+
+	if id == 0 {
+		id = a.nextID
+		a.nextID++
+		defer fmt.Fprintf(w, `{"Success":true,"Message":%d,"Input":{}}`, id)
+	} else {
+		defer fmt.Fprint(w, `{"Success":true,"Message":"SSL test has been updated successfully","Input":{}}`)
+	}
+
+	a.ssl[id] = fmt.Sprintf(`{"id":"%d","domain":%q,"checkrate":%s,"alert_at":%q,"paused":false}`,
+		id, r.PostForm.Get("domain"), r.PostForm.Get("checkrate"), r.PostForm.Get("alert_at"))
+}
@@ -0,0 +1,81 @@
+package statuscake
+
+import (
+	"fmt"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+)
+
+// nodeLocations maps the provider-neutral regions to the node locations of
+// StatusCake. StatusCake has no node locations in Africa.
+var nodeLocations = map[string][]string{
+	"europe":        {"UK1", "UK2", "DE1", "NL1", "FR1"},
+	"north-america": {"US1", "US2", "US3", "CA1"},
+	"south-america": {"BR1"},
+	"asia":          {"SG1", "JP1", "IN1"},
+	"oceania":       {"AU1", "NZ1"},
+}
+
+// translateLocations sets the node locations the test runs from. StatusCake
+// confirms downtime from a number of other locations before it alerts, which
+// is the minimum number of failing locations. Confirmations which are set
+// explicitly, through the spec, an annotation or a rollout policy, take
+// precedence over it.
+func translateLocations(spec v1alpha1.MonitorTemplateSpec, test *Test) error {
+	loc := spec.Locations
+	if loc == nil {
+		return nil
+	}
+
+	if err := provider.ValidateLocations(loc); err != nil {
+		return err
+	}
+
+	for _, region := range loc.Regions {
+		nodes, ok := nodeLocations[region]
+		if !ok {
+			return fmt.Errorf("StatusCake has no locations in %s", region)
+		}
+
+		test.NodeLocations = append(test.NodeLocations, nodes...)
+	}
+
+	if loc.MinFailing == nil {
+		return nil
+	}
+
+	minFailing := *loc.MinFailing
+	if len(test.NodeLocations) > 0 && minFailing > len(test.NodeLocations) {
+		return fmt.Errorf("%d failing locations can't be reached with %d locations", minFailing, len(test.NodeLocations))
+	}
+
+	if spec.Confirmations == nil {
+		test.Confirmation = minFailing
+	}
+	return nil
+}
+
+// nodeRegions returns the regions of the given node locations, in the order of
+// the provider-neutral regions. Unknown node locations are ignored.
+func nodeRegions(nodes []string) []string {
+	found := map[string]bool{}
+	for region, regionNodes := range nodeLocations {
+		for _, node := range regionNodes {
+			for _, n := range nodes {
+				if n == node {
+					found[region] = true
+				}
+			}
+		}
+	}
+
+	var regions []string
+	for _, region := range provider.Regions {
+		if found[region] {
+			regions = append(regions, region)
+		}
+	}
+
+	return regions
+}
@@ -0,0 +1,82 @@
+package statuscake
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
+)
+
+func TestTranslateLocations(t *testing.T) {
+	two, three := 2, 3
+
+	t.Run("with regions", func(t *testing.T) {
+		spec := v1alpha1.MonitorTemplateSpec{
+			Type: "HTTP",
+			Locations: &v1alpha1.LocationTemplate{
+				Regions:    []string{"south-america", "oceania"},
+				MinFailing: &two,
+			},
+		}
+
+		test, err := (&Client{}).translateSpec(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		exp := []string{"BR1", "AU1", "NZ1"}
+		if !reflect.DeepEqual(exp, test.NodeLocations) {
+			t.Errorf("Expected node locations %v, got %v", exp, test.NodeLocations)
+		}
+
+		if test.Confirmation != 2 {
+			t.Errorf("Expected 2 confirmations, got %d", test.Confirmation)
+		}
+	})
+
+	t.Run("with confirmations", func(t *testing.T) {
+		// The confirmations annotation and rollout policies set the
+		// confirmations of templates which set a minimum of failing
+		// locations.
+		spec := v1alpha1.MonitorTemplateSpec{
+			Type:          "HTTP",
+			Confirmations: &three,
+			Locations: &v1alpha1.LocationTemplate{
+				Regions:    []string{"europe"},
+				MinFailing: &two,
+			},
+		}
+
+		test, err := (&Client{}).translateSpec(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if test.Confirmation != 3 {
+			t.Errorf("Expected 3 confirmations, got %d", test.Confirmation)
+		}
+	})
+
+	t.Run("with invalid locations", func(t *testing.T) {
+		for _, spec := range []v1alpha1.MonitorTemplateSpec{
+			{Locations: &v1alpha1.LocationTemplate{Regions: []string{"mars"}}},
+			{Locations: &v1alpha1.LocationTemplate{Regions: []string{"africa"}}},
+			{Locations: &v1alpha1.LocationTemplate{Regions: []string{"south-america"}, MinFailing: &two}},
+		} {
+			if _, err := (&Client{}).translateSpec(spec); !errors.Is(err, provider.ErrInvalidSpec) {
+				t.Errorf("Expected `%s` error for %#v, got `%v`", provider.ErrInvalidSpec, spec.Locations, err)
+			}
+		}
+	})
+
+	t.Run("translated back", func(t *testing.T) {
+		spec := translateTest(&Test{TestType: "HTTP", NodeLocations: []string{"NZ1", "UK2", "XX9"}})
+
+		exp := &v1alpha1.LocationTemplate{Regions: []string{"europe", "oceania"}}
+		if !reflect.DeepEqual(exp, spec.Locations) {
+			t.Errorf("Expected locations %#v, got %#v", exp, spec.Locations)
+		}
+	})
+}
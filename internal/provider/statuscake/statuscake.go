@@ -1,8 +1,14 @@
 package statuscake
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
@@ -10,13 +16,16 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
-
-	"github.com/DreamItGetIT/statuscake"
 )
 
-// The StatusCake Client we're using expects us to set this.
-// XXX remove this once we move to our own internal client.
-const statusCodes = "204,205,206,303,400,401,403,404,405,406,408,410,413,444,429,494,495,496,499,500,501,502,503,504,505,506,507,508,509,510,511,521,522,523,524,520,598,599"
+// defaultStatusCodes are the status codes on which StatusCake alerts when the
+// template doesn't configure any.
+var defaultStatusCodes = []string{
+	"204", "205", "206", "303", "400", "401", "403", "404", "405", "406",
+	"408", "410", "413", "444", "429", "494", "495", "496", "499", "500",
+	"501", "502", "503", "504", "505", "506", "507", "508", "509", "510",
+	"511", "521", "522", "523", "524", "520", "598", "599",
+}
 
 // Register registers the provider with a certain factory using the FactoryFunc.
 func Register(fact provider.FactoryInterface) {
@@ -36,17 +45,8 @@ func FactoryFunc(k8sClient kubernetes.Interface, prov v1alpha1.NamespacedProvide
 		return nil, err
 	}
 
-	auth := statuscake.Auth{
-		Username: username,
-		Apikey:   apiKey,
-	}
-	cl, err := statuscake.New(auth)
-	if err != nil {
-		return nil, err
-	}
-
 	return &Client{
-		cl:     cl.Tests(),
+		cl:     newAPIClient(username, apiKey),
 		groups: prov.StatusCake.ContactGroups,
 	}, nil
 }
@@ -56,7 +56,7 @@ func getSecretValue(cl kubernetes.Interface, ns string, env v1alpha1.SecretVar)
 		return *env.Value, nil
 	}
 
-	secret, err := cl.Core().Secrets(ns).Get(env.ValueFrom.Name, metav1.GetOptions{})
+	secret, err := cl.CoreV1().Secrets(ns).Get(context.TODO(), env.ValueFrom.Name, metav1.GetOptions{})
 	if err != nil {
 		return "", err
 	}
@@ -70,13 +70,39 @@ func getSecretValue(cl kubernetes.Interface, ns string, env v1alpha1.SecretVar)
 }
 
 type statusCakeClient interface {
-	// The client uses Update for both creation and updating.
-	Update(*statuscake.Test) (*statuscake.Test, error)
-	Delete(int) error
+	// The API uses Update for both creation and updating.
+	Update(context.Context, *Test) (int, error)
+	Delete(context.Context, int) error
+	Detail(context.Context, int) (*Test, error)
+	Tests(context.Context) ([]*Test, error)
+
+	UpdateSSL(context.Context, *SSLCheck) (int, error)
+	DeleteSSL(context.Context, int) error
+	SSLChecks(context.Context) ([]*SSLCheck, error)
+}
+
+// sslPrefix prefixes the IDs of SSL checks, as StatusCake manages them
+// separately from tests.
+const sslPrefix = "ssl-"
+
+// defaultSSLCheckRate is the interval at which certificates are checked when
+// the MonitorTemplate doesn't configure a check rate.
+const defaultSSLCheckRate = 24 * time.Hour
+
+// parseID parses the ID of a monitor, which is either the ID of a test or the
+// prefixed ID of an SSL check.
+func parseID(id string) (int, bool, error) {
+	ssl := strings.HasPrefix(id, sslPrefix)
+	iid, err := strconv.ParseInt(strings.TrimPrefix(id, sslPrefix), 10, 64)
+	return int(iid), ssl, err
+}
+
+func isCertificate(spec v1alpha1.MonitorTemplateSpec) bool {
+	return spec.Type == "Certificate"
 }
 
 // Client is a wrapper around the StatusCake API Client. This wrapper provides a
-// mapping from a Provider interface to the actual StatusCake Client.
+// mapping from a Provider interface to the actual StatusCake API.
 type Client struct {
 	cl     statusCakeClient
 	groups []string
@@ -84,91 +110,199 @@ type Client struct {
 
 // Create translates the MonitorTemplateSpec and creates a new instance with
 // StatusCake.
-func (c *Client) Create(spec v1alpha1.MonitorTemplateSpec) (string, error) {
+func (c *Client) Create(ctx context.Context, spec v1alpha1.MonitorTemplateSpec) (string, error) {
+	if isCertificate(spec) {
+		check, err := c.translateCertificate(spec)
+		if err != nil {
+			return "", err
+		}
+
+		checkID, err := c.cl.UpdateSSL(ctx, check)
+		if err != nil {
+			return "", err
+		}
+
+		return sslPrefix + strconv.Itoa(checkID), nil
+	}
+
 	translation, err := c.translateSpec(spec)
 	if err != nil {
 		return "", err
 	}
 
-	test, err := c.cl.Update(translation)
+	testID, err := c.cl.Update(ctx, translation)
 	if err != nil {
 		return "", err
 	}
 
-	return strconv.Itoa(test.TestID), nil
+	return strconv.Itoa(testID), assertionsError(spec)
 }
 
 // Delete deletes the monitor which is linked to the given ID from StatusCake.
-func (c *Client) Delete(id string) error {
-	iid, err := strconv.ParseInt(id, 10, 64)
+func (c *Client) Delete(ctx context.Context, id string) error {
+	iid, ssl, err := parseID(id)
 	if err != nil {
 		return err
 	}
 
-	return c.cl.Delete(int(iid))
+	if ssl {
+		return c.cl.DeleteSSL(ctx, iid)
+	}
+
+	return c.cl.Delete(ctx, iid)
 }
 
 // Update updates the Monitor linked to the given ID with the new configuration.
-func (c *Client) Update(id string, spec v1alpha1.MonitorTemplateSpec) (string, error) {
-	iid, err := strconv.ParseInt(id, 10, 64)
+func (c *Client) Update(ctx context.Context, id string, spec v1alpha1.MonitorTemplateSpec) (string, error) {
+	iid, ssl, err := parseID(id)
 	if err != nil {
 		return id, err
 	}
 
-	translation, err := c.translateSpec(spec)
-	if err != nil {
-		return id, err
+	// Tests can't be turned into SSL checks or the other way around. The old
+	// monitor is removed and reported as not found, so the Operator creates
+	// a new one.
+	if ssl != isCertificate(spec) {
+		if err := c.Delete(ctx, id); err != nil && !errors.Is(err, provider.ErrNotFound) {
+			return id, err
+		}
+
+		return id, provider.ErrNotFound
 	}
 
-	translation.TestID = int(iid)
-	sct, err := c.cl.Update(translation)
-
-	if err != nil && err.Error() == fmt.Sprintf("No matching key can be found on this account. Given: %s", id) {
-		// XXX see if the API returns a 404 HTTP StatusCode, if so, we should add
-		// our own client to add proper error handling. This will do for now.
-		translation.TestID = 0
-		sct, err = c.cl.Update(translation)
-	} else if err != nil && err.Error() == fmt.Sprintf("No data has been updated (is any data different?) Given: %s", id) {
-		// XXX see if we get a proper status code back for this kind of error,
-		// it's not really an error.
-		// We want to keep doing these calls to ensure that the monitor is how
-		// it should be configured in our specs.
-		return id, nil
-	} else if err != nil && err.Error() == fmt.Sprintf("No data has been updated (is any data different?)") {
-		// XXX see if we get a proper status code back for this kind of error,
-		// it's not really an error.
-		// We want to keep doing these calls to ensure that the monitor is how
-		// it should be configured in our specs.
-		return id, nil
+	if ssl {
+		check, err := c.translateCertificate(spec)
+		if err != nil {
+			return id, err
+		}
+
+		check.ID = iid
+		_, err = c.cl.UpdateSSL(ctx, check)
+		return id, err
 	}
 
+	translation, err := c.translateSpec(spec)
 	if err != nil {
 		return id, err
 	}
 
+	translation.TestID = iid
+	testID, err := c.cl.Update(ctx, translation)
+	if errors.Is(err, provider.ErrNoChange) {
+		// The test is up to date, but the assertions it can't check are
+		// still reported.
+		if aerr := assertionsError(spec); aerr != nil {
+			return id, aerr
+		}
+		return id, err
+	} else if err != nil {
+		return id, err
+	}
+
 	// The StatusCake API returns no ID if there is an update to the item. This
 	// means we need to check for this and actually avoid returning a "0" id.
-	if sct.TestID == 0 {
-		return id, nil
+	if testID == 0 {
+		return id, assertionsError(spec)
+	}
+
+	return strconv.Itoa(testID), assertionsError(spec)
+}
+
+// List fetches all the tests and SSL checks which are configured in the
+// StatusCake account.
+func (c *Client) List(ctx context.Context) ([]provider.Monitor, error) {
+	tests, err := c.cl.Tests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, err := c.cl.SSLChecks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	monitors := make([]provider.Monitor, 0, len(tests)+len(checks))
+	for _, test := range tests {
+		monitors = append(monitors, provider.Monitor{
+			ID:   strconv.Itoa(test.TestID),
+			Spec: translateTest(test),
+		})
 	}
 
-	return strconv.Itoa(sct.TestID), nil
+	for _, check := range checks {
+		monitors = append(monitors, provider.Monitor{
+			ID:   sslPrefix + strconv.Itoa(check.ID),
+			Spec: translateSSLCheck(check),
+		})
+	}
+
+	return monitors, nil
+}
+
+// Pause pauses the test with the given ID. StatusCake can't pause SSL
+// checks.
+func (c *Client) Pause(ctx context.Context, id string) error {
+	return c.setPaused(ctx, id, true)
+}
+
+// Resume resumes the test with the given ID.
+func (c *Client) Resume(ctx context.Context, id string) error {
+	return c.setPaused(ctx, id, false)
+}
+
+// setPaused fetches the test with the given ID and updates it with the given
+// paused state. The rest of the test is sent back as it is, so it isn't
+// changed.
+func (c *Client) setPaused(ctx context.Context, id string, paused bool) error {
+	iid, ssl, err := parseID(id)
+	if err != nil {
+		return err
+	}
+
+	if ssl {
+		return provider.ErrPauseUnsupported
+	}
+
+	test, err := c.cl.Detail(ctx, iid)
+	if err != nil {
+		return err
+	}
+
+	if (test.Paused != nil && *test.Paused) == paused {
+		return nil
+	}
+
+	test.Paused = &paused
+	if _, err = c.cl.Update(ctx, test); errors.Is(err, provider.ErrNoChange) {
+		return nil
+	}
+	return err
 }
 
 // translateSpec does the actual translation from a MonitorTemplateSpec to a
-// StatusCake Test.
-func (c *Client) translateSpec(spec v1alpha1.MonitorTemplateSpec) (*statuscake.Test, error) {
-	scTest := &statuscake.Test{
+// StatusCake Test. Certificate checks aren't tests, they're translated by
+// translateCertificate.
+func (c *Client) translateSpec(spec v1alpha1.MonitorTemplateSpec) (*Test, error) {
+	testType := spec.Type
+	switch testType {
+	case "":
+		testType = "HTTP"
+	case "HTTP", "TCP", "DNS":
+	default:
+		return nil, &provider.UnsupportedTypeError{Provider: "StatusCake", Type: spec.Type}
+	}
+
+	scTest := &Test{
 		WebsiteName:  spec.Name,
-		TestType:     spec.Type,
+		TestType:     testType,
 		ContactGroup: c.groups,
-		StatusCodes:  statusCodes,
+		StatusCodes:  defaultStatusCodes,
 	}
 
 	if spec.Timeout != nil {
 		tm, err := time.ParseDuration(*spec.Timeout)
 		if err != nil {
-			return nil, err
+			return nil, provider.InvalidSpec(err)
 		}
 
 		scTest.Timeout = int(tm.Seconds())
@@ -177,7 +311,7 @@ func (c *Client) translateSpec(spec v1alpha1.MonitorTemplateSpec) (*statuscake.T
 	if spec.CheckRate != nil {
 		tm, err := time.ParseDuration(*spec.CheckRate)
 		if err != nil {
-			return nil, err
+			return nil, provider.InvalidSpec(err)
 		}
 
 		scTest.CheckRate = int(tm.Seconds())
@@ -187,6 +321,31 @@ func (c *Client) translateSpec(spec v1alpha1.MonitorTemplateSpec) (*statuscake.T
 		scTest.Confirmation = *spec.Confirmations
 	}
 
+	if err := translateLocations(spec, scTest); err != nil {
+		return nil, provider.InvalidSpec(err)
+	}
+
+	if spec.Type == "TCP" {
+		if spec.TCP == nil || spec.TCP.Host == "" || spec.TCP.Port == 0 {
+			return nil, provider.InvalidSpec(errors.New("TCP checks need a host and a port"))
+		}
+
+		scTest.WebsiteURL = spec.TCP.Host
+		scTest.Port = int(spec.TCP.Port)
+		return scTest, nil
+	}
+
+	if spec.Type == "DNS" {
+		if spec.DNS == nil || spec.DNS.Host == "" || len(spec.DNS.ExpectedRecords) == 0 {
+			return nil, provider.InvalidSpec(errors.New("DNS checks need a host and the records it should resolve to"))
+		}
+
+		scTest.WebsiteURL = spec.DNS.Host
+		scTest.DNSIP = strings.Join(spec.DNS.ExpectedRecords, ",")
+		scTest.DNSServer = spec.DNS.Server
+		return scTest, nil
+	}
+
 	if http := spec.HTTP; http != nil {
 		scTest.CustomHeader = http.CustomHeader
 		scTest.UserAgent = http.UserAgent
@@ -199,7 +358,368 @@ func (c *Client) translateSpec(spec v1alpha1.MonitorTemplateSpec) (*statuscake.T
 			scTest.FindString = spec.HTTP.ShouldNotContain
 			scTest.DoNotFind = true
 		}
+
+		codes, err := statusCodes(spec.HTTP)
+		if err != nil {
+			return nil, provider.InvalidSpec(err)
+		}
+		scTest.StatusCodes = codes
+
+		if err := translateRequest(http, scTest); err != nil {
+			return nil, provider.InvalidSpec(err)
+		}
+
+		if _, err := translateAssertions(http, scTest); err != nil {
+			return nil, provider.InvalidSpec(err)
+		}
 	}
 
 	return scTest, nil
 }
+
+// translateAssertions translates the assertions StatusCake can express into
+// the test, and returns descriptions of the ones it can't. StatusCake only
+// looks for a single plain string in the body, which is also used by
+// ShouldContain and ShouldNotContain, and fails the check when the response
+// takes longer than its timeout.
+func translateAssertions(http *v1alpha1.HTTPTemplate, test *Test) ([]string, error) {
+	var unsupported []string
+
+	findString := http.ShouldNotContain != "" || http.ShouldContain != ""
+	if http.ShouldContain != "" && http.ShouldNotContain != "" {
+		unsupported = append(unsupported, fmt.Sprintf("shouldContain %q together with shouldNotContain", http.ShouldContain))
+	}
+
+	for _, a := range http.Assertions {
+		if err := provider.ValidateAssertion(a); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case a.Regex != "":
+			// Regular expressions without any special characters are plain
+			// strings.
+			prefix, complete := regexp.MustCompile(a.Regex).LiteralPrefix()
+			if complete && !findString {
+				test.FindString = prefix
+				findString = true
+				continue
+			}
+		case a.MaxResponseTime != "":
+			d, _ := provider.MaxResponseTime(a)
+			timeout := int(math.Ceil(d.Seconds()))
+			if test.Timeout == 0 || timeout < test.Timeout {
+				test.Timeout = timeout
+			}
+			continue
+		}
+
+		unsupported = append(unsupported, provider.DescribeAssertion(a))
+	}
+
+	return unsupported, nil
+}
+
+// assertionsError reports the assertions of the spec StatusCake can't
+// express.
+func assertionsError(spec v1alpha1.MonitorTemplateSpec) error {
+	if spec.HTTP == nil || (spec.Type != "" && spec.Type != "HTTP") {
+		return nil
+	}
+
+	unsupported, _ := translateAssertions(spec.HTTP, new(Test))
+	return provider.UnsupportedAssertions("StatusCake", unsupported)
+}
+
+// translateRequest sets up the request StatusCake sends for the check.
+// StatusCake sends a POST when the check has a body and a GET otherwise, and
+// expects the custom headers as a JSON object.
+func translateRequest(http *v1alpha1.HTTPTemplate, test *Test) error {
+	switch strings.ToUpper(http.Method) {
+	case "", "GET":
+		if http.Body != "" {
+			return errors.New("StatusCake only sends a body with POST checks")
+		}
+	case "POST":
+		if http.Body == "" {
+			return errors.New("StatusCake needs a body for POST checks")
+		}
+		test.PostRaw = http.Body
+	default:
+		return fmt.Errorf("StatusCake doesn't support %s checks", http.Method)
+	}
+
+	if auth := http.BasicAuth; auth != nil {
+		user, err := provider.SecretValue(auth.Username)
+		if err != nil {
+			return err
+		}
+
+		pass, err := provider.SecretValue(auth.Password)
+		if err != nil {
+			return err
+		}
+
+		test.BasicUser, test.BasicPass = user, pass
+	}
+
+	headers := map[string]string{}
+	for key, val := range http.Headers {
+		headers[key] = val
+	}
+
+	if http.BearerToken != nil {
+		token, err := provider.SecretValue(*http.BearerToken)
+		if err != nil {
+			return err
+		}
+
+		headers["Authorization"] = "Bearer " + token
+	}
+
+	if len(headers) == 0 {
+		return nil
+	}
+
+	if http.CustomHeader != "" {
+		return errors.New("customHeader can't be combined with headers or a bearer token")
+	}
+
+	raw, err := json.Marshal(headers)
+	if err != nil {
+		return err
+	}
+
+	test.CustomHeader = string(raw)
+	return nil
+}
+
+// statusCodes returns the status codes StatusCake should alert on for the
+// given template. StatusCake has no notion of expected status codes, so
+// these are left out of the codes it alerts on.
+func statusCodes(http *v1alpha1.HTTPTemplate) ([]string, error) {
+	codes := defaultStatusCodes
+	if len(http.AlertStatusCodes) > 0 {
+		codes = make([]string, 0, len(http.AlertStatusCodes))
+		for _, code := range http.AlertStatusCodes {
+			if err := validStatusCode(code); err != nil {
+				return nil, err
+			}
+
+			codes = append(codes, strconv.Itoa(code))
+		}
+	}
+
+	if len(http.ExpectedStatusCodes) == 0 {
+		return codes, nil
+	}
+
+	expected := map[string]bool{}
+	for _, code := range http.ExpectedStatusCodes {
+		if err := validStatusCode(code); err != nil {
+			return nil, err
+		}
+
+		expected[strconv.Itoa(code)] = true
+	}
+
+	alert := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if !expected[code] {
+			alert = append(alert, code)
+		}
+	}
+
+	if len(alert) == 0 {
+		return nil, errors.New("all the status codes to alert on are expected")
+	}
+
+	return alert, nil
+}
+
+func validStatusCode(code int) error {
+	if code < 100 || code > 599 {
+		return fmt.Errorf("invalid status code %d", code)
+	}
+
+	return nil
+}
+
+// translateTest translates a StatusCake Test back into a MonitorTemplateSpec.
+// Settings which StatusCake doesn't return, like the endpoint, are left empty.
+func translateTest(test *Test) v1alpha1.MonitorTemplateSpec {
+	spec := v1alpha1.MonitorTemplateSpec{
+		Name: test.WebsiteName,
+		Type: test.TestType,
+	}
+
+	if test.Timeout != 0 {
+		tm := (time.Duration(test.Timeout) * time.Second).String()
+		spec.Timeout = &tm
+	}
+
+	if test.CheckRate != 0 {
+		rate := (time.Duration(test.CheckRate) * time.Second).String()
+		spec.CheckRate = &rate
+	}
+
+	if test.Confirmation != 0 {
+		confirmations := test.Confirmation
+		spec.Confirmations = &confirmations
+	}
+
+	if regions := nodeRegions(test.NodeLocations); len(regions) > 0 {
+		spec.Locations = &v1alpha1.LocationTemplate{Regions: regions}
+	}
+
+	if test.TestType == "TCP" {
+		spec.TCP = &v1alpha1.TCPTemplate{
+			Host: test.WebsiteURL,
+			Port: int32(test.Port),
+		}
+	}
+
+	if test.TestType == "DNS" {
+		spec.DNS = &v1alpha1.DNSTemplate{
+			Host:   test.WebsiteURL,
+			Server: test.DNSServer,
+		}
+
+		for _, record := range strings.Split(test.DNSIP, ",") {
+			if record = strings.TrimSpace(record); record != "" {
+				spec.DNS.ExpectedRecords = append(spec.DNS.ExpectedRecords, record)
+			}
+		}
+	}
+
+	if test.TestType == "HTTP" {
+		spec.HTTP = &v1alpha1.HTTPTemplate{
+			URL:               test.WebsiteURL,
+			CustomHeader:      test.CustomHeader,
+			UserAgent:         test.UserAgent,
+			FollowRedirects:   test.FollowRedirect,
+			VerifyCertificate: test.EnableSSLAlert,
+		}
+
+		if test.DoNotFind {
+			spec.HTTP.ShouldNotContain = test.FindString
+		} else {
+			spec.HTTP.ShouldContain = test.FindString
+		}
+
+		if test.PostRaw != "" {
+			spec.HTTP.Method = "POST"
+			spec.HTTP.Body = test.PostRaw
+		}
+
+		// Headers are sent as a JSON object, the credentials in them are
+		// left out like the basic authentication credentials.
+		var headers map[string]string
+		if err := json.Unmarshal([]byte(test.CustomHeader), &headers); err == nil {
+			spec.HTTP.CustomHeader = ""
+			delete(headers, "Authorization")
+			if len(headers) > 0 {
+				spec.HTTP.Headers = headers
+			}
+		}
+
+		if len(test.StatusCodes) > 0 && !sameCodes(test.StatusCodes, defaultStatusCodes) {
+			for _, code := range test.StatusCodes {
+				if i, err := strconv.Atoi(code); err == nil {
+					spec.HTTP.AlertStatusCodes = append(spec.HTTP.AlertStatusCodes, i)
+				}
+			}
+		}
+	}
+
+	return spec
+}
+
+// translateCertificate translates a MonitorTemplateSpec for a certificate
+// check into a StatusCake SSL check.
+func (c *Client) translateCertificate(spec v1alpha1.MonitorTemplateSpec) (*SSLCheck, error) {
+	cert := spec.Certificate
+	if cert == nil || cert.Host == "" {
+		return nil, provider.InvalidSpec(errors.New("certificate checks need a host"))
+	}
+
+	thresholds, err := provider.CertificateThresholds(cert)
+	if err != nil {
+		return nil, provider.InvalidSpec(err)
+	}
+
+	if len(thresholds) != 3 {
+		return nil, provider.InvalidSpec(fmt.Errorf("StatusCake needs exactly 3 warning thresholds, got %d", len(thresholds)))
+	}
+
+	check := &SSLCheck{
+		Domain:        "https://" + cert.Host,
+		CheckRate:     int(defaultSSLCheckRate.Seconds()),
+		ContactGroups: c.groups,
+		AlertExpiry:   true,
+		AlertReminder: true,
+		AlertBroken:   true,
+	}
+
+	// StatusCake alerts a number of days before the certificate expires,
+	// from the shortest to the longest threshold.
+	for i := len(thresholds) - 1; i >= 0; i-- {
+		days := int(math.Ceil(thresholds[i].Hours() / 24))
+		check.AlertAt = append(check.AlertAt, days)
+	}
+
+	if spec.CheckRate != nil {
+		tm, err := time.ParseDuration(*spec.CheckRate)
+		if err != nil {
+			return nil, provider.InvalidSpec(err)
+		}
+
+		check.CheckRate = int(tm.Seconds())
+	}
+
+	return check, nil
+}
+
+// translateSSLCheck translates a StatusCake SSL check back into a
+// MonitorTemplateSpec. SSL checks don't have a name, so the host is used.
+func translateSSLCheck(check *SSLCheck) v1alpha1.MonitorTemplateSpec {
+	host := strings.TrimPrefix(check.Domain, "https://")
+	spec := v1alpha1.MonitorTemplateSpec{
+		Name:        host,
+		Type:        "Certificate",
+		Certificate: &v1alpha1.CertificateTemplate{Host: host},
+	}
+
+	if check.CheckRate != 0 {
+		rate := (time.Duration(check.CheckRate) * time.Second).String()
+		spec.CheckRate = &rate
+	}
+
+	for i := len(check.AlertAt) - 1; i >= 0; i-- {
+		threshold := (time.Duration(check.AlertAt[i]) * 24 * time.Hour).String()
+		spec.Certificate.WarningThresholds = append(spec.Certificate.WarningThresholds, threshold)
+	}
+
+	return spec
+}
+
+// sameCodes reports if both lists contain the same status codes, regardless
+// of their order.
+func sameCodes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	codes := map[string]bool{}
+	for _, code := range a {
+		codes[code] = true
+	}
+
+	for _, code := range b {
+		if !codes[code] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,14 +1,14 @@
 package statuscake
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"strconv"
 	"testing"
 
 	"github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
-
-	"github.com/DreamItGetIT/statuscake"
+	"github.com/jelmersnoeck/ingress-monitor/internal/provider"
 
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -120,7 +120,7 @@ func TestTranslateSpec(t *testing.T) {
 		name     string
 		spec     v1alpha1.MonitorTemplateSpec
 		groups   []string
-		expected *statuscake.Test
+		expected *Test
 	}{
 		{
 			"simple HTTP config",
@@ -133,7 +133,7 @@ func TestTranslateSpec(t *testing.T) {
 				},
 			},
 			nil,
-			&statuscake.Test{
+			&Test{
 				TestType:       "HTTP",
 				CustomHeader:   "Test-Header",
 				UserAgent:      "(Test User Agent)",
@@ -156,7 +156,7 @@ func TestTranslateSpec(t *testing.T) {
 				},
 			},
 			nil,
-			&statuscake.Test{
+			&Test{
 				TestType:       "HTTP",
 				CustomHeader:   "Test-Header",
 				UserAgent:      "(Test User Agent)",
@@ -181,7 +181,7 @@ func TestTranslateSpec(t *testing.T) {
 				},
 			},
 			nil,
-			&statuscake.Test{
+			&Test{
 				TestType:       "HTTP",
 				CustomHeader:   "Test-Header",
 				UserAgent:      "(Test User Agent)",
@@ -205,7 +205,7 @@ func TestTranslateSpec(t *testing.T) {
 				},
 			},
 			[]string{"12345"},
-			&statuscake.Test{
+			&Test{
 				TestType:       "HTTP",
 				CustomHeader:   "Test-Header",
 				UserAgent:      "(Test User Agent)",
@@ -215,6 +215,101 @@ func TestTranslateSpec(t *testing.T) {
 				EnableSSLAlert: true,
 			},
 		},
+		{
+			"TCP config",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "TCP",
+				TCP: &v1alpha1.TCPTemplate{
+					Host: "db.example.com",
+					Port: 5432,
+				},
+			},
+			nil,
+			&Test{
+				TestType:   "TCP",
+				WebsiteURL: "db.example.com",
+				Port:       5432,
+			},
+		},
+		{
+			"HTTP config with a request",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:    "http://fully-qualified-url.com",
+					Method: "post",
+					Body:   `{"ping":true}`,
+					Headers: map[string]string{
+						"Content-Type": "application/json",
+					},
+					BasicAuth: &v1alpha1.BasicAuth{
+						Username: v1alpha1.SecretVar{Value: ptrString("user")},
+						Password: v1alpha1.SecretVar{Value: ptrString("pass")},
+					},
+					BearerToken: &v1alpha1.SecretVar{Value: ptrString("token")},
+				},
+			},
+			nil,
+			&Test{
+				TestType:     "HTTP",
+				WebsiteURL:   "http://fully-qualified-url.com",
+				PostRaw:      `{"ping":true}`,
+				CustomHeader: `{"Authorization":"Bearer token","Content-Type":"application/json"}`,
+				BasicUser:    "user",
+				BasicPass:    "pass",
+			},
+		},
+		{
+			"DNS config",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "DNS",
+				DNS: &v1alpha1.DNSTemplate{
+					Host:            "api.example.com",
+					ExpectedRecords: []string{"203.0.113.10", "203.0.113.11"},
+					Server:          "8.8.8.8",
+				},
+			},
+			nil,
+			&Test{
+				TestType:   "DNS",
+				WebsiteURL: "api.example.com",
+				DNSIP:      "203.0.113.10,203.0.113.11",
+				DNSServer:  "8.8.8.8",
+			},
+		},
+		{
+			"HTTP config with alert status codes",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:              "http://fully-qualified-url.com",
+					AlertStatusCodes: []int{500, 502, 503},
+				},
+			},
+			nil,
+			&Test{
+				TestType:    "HTTP",
+				WebsiteURL:  "http://fully-qualified-url.com",
+				StatusCodes: []string{"500", "502", "503"},
+			},
+		},
+		{
+			"HTTP config with alert and expected status codes",
+			v1alpha1.MonitorTemplateSpec{
+				Type: "HTTP",
+				HTTP: &v1alpha1.HTTPTemplate{
+					URL:                 "http://fully-qualified-url.com",
+					AlertStatusCodes:    []int{500, 502, 503},
+					ExpectedStatusCodes: []int{503},
+				},
+			},
+			nil,
+			&Test{
+				TestType:    "HTTP",
+				WebsiteURL:  "http://fully-qualified-url.com",
+				StatusCodes: []string{"500", "502"},
+			},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -227,7 +322,9 @@ func TestTranslateSpec(t *testing.T) {
 			}
 
 			exp := tc.expected
-			exp.StatusCodes = statusCodes
+			if exp.StatusCodes == nil {
+				exp.StatusCodes = defaultStatusCodes
+			}
 
 			if !reflect.DeepEqual(translation, exp) {
 				t.Errorf("Expected translation to equal \n%#v\ngot\n%#v", exp, translation)
@@ -236,6 +333,206 @@ func TestTranslateSpec(t *testing.T) {
 	}
 }
 
+func TestTranslateSpec_Type(t *testing.T) {
+	t.Run("without a type", func(t *testing.T) {
+		test, err := (&Client{}).translateSpec(v1alpha1.MonitorTemplateSpec{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if test.TestType != "HTTP" {
+			t.Errorf("Expected TestType to be `HTTP`, got `%s`", test.TestType)
+		}
+	})
+
+	t.Run("with an unknown type", func(t *testing.T) {
+		_, err := (&Client{}).translateSpec(v1alpha1.MonitorTemplateSpec{Type: "PING"})
+
+		var unsupported *provider.UnsupportedTypeError
+		if !errors.As(err, &unsupported) || unsupported.Type != "PING" {
+			t.Errorf("Expected an UnsupportedTypeError, got `%v`", err)
+		}
+	})
+}
+
+func TestTranslateSpec_TCP(t *testing.T) {
+	for _, tcp := range []*v1alpha1.TCPTemplate{
+		nil,
+		{Port: 5432},
+		{Host: "db.example.com"},
+	} {
+		spec := v1alpha1.MonitorTemplateSpec{Type: "TCP", TCP: tcp}
+		if _, err := (&Client{}).translateSpec(spec); !errors.Is(err, provider.ErrInvalidSpec) {
+			t.Errorf("Expected `%s` error for %#v, got `%v`", provider.ErrInvalidSpec, tcp, err)
+		}
+	}
+}
+
+func TestTranslateSpec_DNS(t *testing.T) {
+	t.Run("with an invalid spec", func(t *testing.T) {
+		for _, dns := range []*v1alpha1.DNSTemplate{
+			nil,
+			{Host: "api.example.com"},
+			{ExpectedRecords: []string{"203.0.113.10"}},
+		} {
+			spec := v1alpha1.MonitorTemplateSpec{Type: "DNS", DNS: dns}
+			if _, err := (&Client{}).translateSpec(spec); !errors.Is(err, provider.ErrInvalidSpec) {
+				t.Errorf("Expected `%s` error for %#v, got `%v`", provider.ErrInvalidSpec, dns, err)
+			}
+		}
+	})
+
+	t.Run("translated back", func(t *testing.T) {
+		spec := translateTest(&Test{TestType: "DNS", WebsiteURL: "api.example.com", DNSIP: "203.0.113.10, lb.example.com"})
+
+		exp := &v1alpha1.DNSTemplate{
+			Host:            "api.example.com",
+			ExpectedRecords: []string{"203.0.113.10", "lb.example.com"},
+		}
+		if !reflect.DeepEqual(exp, spec.DNS) {
+			t.Errorf("Expected DNS template %#v, got %#v", exp, spec.DNS)
+		}
+	})
+}
+
+func TestTranslateRequest(t *testing.T) {
+	t.Run("with an invalid request", func(t *testing.T) {
+		for _, http := range []*v1alpha1.HTTPTemplate{
+			{Method: "PUT", Body: "{}"},
+			{Method: "POST"},
+			{Body: "{}"},
+			{CustomHeader: "X-Custom: yes", Headers: map[string]string{"X-Other": "yes"}},
+			{BearerToken: &v1alpha1.SecretVar{}},
+		} {
+			if err := translateRequest(http, new(Test)); err == nil {
+				t.Errorf("Expected an error for %#v", http)
+			}
+		}
+	})
+
+	t.Run("translated back", func(t *testing.T) {
+		spec := translateTest(&Test{
+			TestType:     "HTTP",
+			PostRaw:      "{}",
+			CustomHeader: `{"Authorization":"Bearer token","X-Custom":"yes"}`,
+		})
+
+		if spec.HTTP.Method != "POST" || spec.HTTP.Body != "{}" {
+			t.Errorf("Expected a POST with a body, got %#v", spec.HTTP)
+		}
+
+		exp := map[string]string{"X-Custom": "yes"}
+		if spec.HTTP.CustomHeader != "" || !reflect.DeepEqual(exp, spec.HTTP.Headers) {
+			t.Errorf("Expected headers %v without the credentials, got %#v", exp, spec.HTTP)
+		}
+	})
+}
+
+func TestTranslateAssertions(t *testing.T) {
+	t.Run("with supported assertions", func(t *testing.T) {
+		test := &Test{Timeout: 30}
+		unsupported, err := translateAssertions(&v1alpha1.HTTPTemplate{
+			Assertions: []v1alpha1.Assertion{
+				{Regex: "healthy"},
+				{MaxResponseTime: "2500ms"},
+			},
+		}, test)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if len(unsupported) != 0 {
+			t.Errorf("Expected all assertions to be supported, got %v", unsupported)
+		}
+
+		if test.FindString != "healthy" || test.Timeout != 3 {
+			t.Errorf("Expected the find string and timeout to be set, got %#v", test)
+		}
+	})
+
+	t.Run("with unsupported assertions", func(t *testing.T) {
+		unsupported, err := translateAssertions(&v1alpha1.HTTPTemplate{
+			ShouldContain:    "OK",
+			ShouldNotContain: "Bad Gateway",
+			Assertions: []v1alpha1.Assertion{
+				{Regex: "healthy"},
+				{Regex: "^ok$"},
+				{JSONPath: &v1alpha1.JSONPathAssertion{Path: "$.status", Equals: "ok"}},
+				{Header: &v1alpha1.HeaderAssertion{Name: "X-Version", Equals: "2"}},
+			},
+		}, new(Test))
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		exp := []string{
+			`shouldContain "OK" together with shouldNotContain`,
+			`regex "healthy"`,
+			`regex "^ok$"`,
+			`jsonPath $.status equals "ok"`,
+			`header X-Version equals "2"`,
+		}
+		if !reflect.DeepEqual(exp, unsupported) {
+			t.Errorf("Expected unsupported assertions %v, got %v", exp, unsupported)
+		}
+	})
+
+	t.Run("with an invalid assertion", func(t *testing.T) {
+		spec := v1alpha1.MonitorTemplateSpec{
+			Type: "HTTP",
+			HTTP: &v1alpha1.HTTPTemplate{
+				Assertions: []v1alpha1.Assertion{{Regex: "(", MaxResponseTime: "1s"}},
+			},
+		}
+
+		if _, err := (&Client{}).translateSpec(spec); !errors.Is(err, provider.ErrInvalidSpec) {
+			t.Errorf("Expected `%s` error, got `%v`", provider.ErrInvalidSpec, err)
+		}
+	})
+}
+
+func TestStatusCodes(t *testing.T) {
+	t.Run("with expected status codes", func(t *testing.T) {
+		codes, err := statusCodes(&v1alpha1.HTTPTemplate{ExpectedStatusCodes: []int{401, 503}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if len(codes) != len(defaultStatusCodes)-2 {
+			t.Errorf("Expected %d status codes, got %d", len(defaultStatusCodes)-2, len(codes))
+		}
+
+		for _, code := range codes {
+			if code == "401" || code == "503" {
+				t.Errorf("Expected %s not to be alerted on", code)
+			}
+		}
+	})
+
+	t.Run("with invalid status codes", func(t *testing.T) {
+		for _, http := range []*v1alpha1.HTTPTemplate{
+			{AlertStatusCodes: []int{5000}},
+			{ExpectedStatusCodes: []int{0}},
+			{AlertStatusCodes: []int{503}, ExpectedStatusCodes: []int{503}},
+		} {
+			if _, err := statusCodes(http); err == nil {
+				t.Errorf("Expected an error for %#v", http)
+			}
+		}
+	})
+
+	t.Run("translated as an invalid spec", func(t *testing.T) {
+		spec := v1alpha1.MonitorTemplateSpec{
+			Type: "HTTP",
+			HTTP: &v1alpha1.HTTPTemplate{AlertStatusCodes: []int{1}},
+		}
+
+		if _, err := (&Client{}).translateSpec(spec); !errors.Is(err, provider.ErrInvalidSpec) {
+			t.Errorf("Expected `%s` error, got `%v`", provider.ErrInvalidSpec, err)
+		}
+	})
+}
+
 func TestClient_Delete(t *testing.T) {
 	fc := new(fakeClient)
 	cl := &Client{cl: fc}
@@ -243,7 +540,7 @@ func TestClient_Delete(t *testing.T) {
 	t.Run("without an error", func(t *testing.T) {
 		defer fc.flush()
 
-		fc.deleteFunc = func(i int) error {
+		fc.deleteFunc = func(_ context.Context, i int) error {
 			if strconv.Itoa(i) != "12345" {
 				t.Errorf("Expected id `12345`, got `%d`", i)
 			}
@@ -251,7 +548,7 @@ func TestClient_Delete(t *testing.T) {
 			return nil
 		}
 
-		if err := cl.Delete("12345"); err != nil {
+		if err := cl.Delete(context.Background(), "12345"); err != nil {
 			t.Errorf("Expected no error, got %s", err)
 		}
 
@@ -264,7 +561,7 @@ func TestClient_Delete(t *testing.T) {
 		t.Run("invalid number", func(t *testing.T) {
 			defer fc.flush()
 
-			if err := cl.Delete("not-a-number"); err == nil {
+			if err := cl.Delete(context.Background(), "not-a-number"); err == nil {
 				t.Errorf("Expected an error, got none")
 			}
 
@@ -273,11 +570,23 @@ func TestClient_Delete(t *testing.T) {
 			}
 		})
 
+		t.Run("monitor not found", func(t *testing.T) {
+			defer fc.flush()
+
+			fc.deleteFunc = func(_ context.Context, i int) error {
+				return &APIError{StatusCode: 200, Message: "No matching key can be found on this account.", err: provider.ErrNotFound}
+			}
+
+			if err := cl.Delete(context.Background(), "12345"); !errors.Is(err, provider.ErrNotFound) {
+				t.Errorf("Expected `%s` error, got `%s`", provider.ErrNotFound, err)
+			}
+		})
+
 		t.Run("statuscake error", func(t *testing.T) {
 			defer fc.flush()
 
 			scError := errors.New("statuscake error")
-			fc.deleteFunc = func(i int) error {
+			fc.deleteFunc = func(_ context.Context, i int) error {
 				if strconv.Itoa(i) != "12345" {
 					t.Errorf("Expected id `12345`, got `%d`", i)
 				}
@@ -285,7 +594,7 @@ func TestClient_Delete(t *testing.T) {
 				return scError
 			}
 
-			if err := cl.Delete("12345"); err != scError {
+			if err := cl.Delete(context.Background(), "12345"); err != scError {
 				t.Errorf("Expected `%s` error, got `%s`", scError, err)
 			}
 
@@ -312,12 +621,11 @@ func TestClient_Create(t *testing.T) {
 			},
 		}
 
-		fc.updateFunc = func(sct *statuscake.Test) (*statuscake.Test, error) {
-			sct.TestID = 12345
-			return sct, nil
+		fc.updateFunc = func(_ context.Context, sct *Test) (int, error) {
+			return 12345, nil
 		}
 
-		id, err := cl.Create(tpl)
+		id, err := cl.Create(context.Background(), tpl)
 		if err != nil {
 			t.Errorf("Expected no error, got %s", err)
 		}
@@ -331,6 +639,32 @@ func TestClient_Create(t *testing.T) {
 		}
 	})
 
+	t.Run("with unsupported assertions", func(t *testing.T) {
+		defer fc.flush()
+
+		tpl := v1alpha1.MonitorTemplateSpec{
+			Type: "HTTP",
+			HTTP: &v1alpha1.HTTPTemplate{
+				URL:        "http://fully-qualified-url.com",
+				Assertions: []v1alpha1.Assertion{{Header: &v1alpha1.HeaderAssertion{Name: "X-Version"}}},
+			},
+		}
+
+		fc.updateFunc = func(_ context.Context, sct *Test) (int, error) {
+			return 12345, nil
+		}
+
+		id, err := cl.Create(context.Background(), tpl)
+		var unsupported *provider.UnsupportedAssertionsError
+		if !errors.As(err, &unsupported) || !reflect.DeepEqual([]string{"header X-Version is present"}, unsupported.Assertions) {
+			t.Errorf("Expected the header assertion to be reported, got %v", err)
+		}
+
+		if id != "12345" {
+			t.Errorf("Expected the monitor to be created anyway, got ID `%s`", id)
+		}
+	})
+
 	t.Run("with translation error", func(t *testing.T) {
 		defer fc.flush()
 
@@ -344,9 +678,9 @@ func TestClient_Create(t *testing.T) {
 			},
 		}
 
-		_, err := cl.Create(tpl)
-		if err == nil {
-			t.Errorf("Expected error, got none")
+		_, err := cl.Create(context.Background(), tpl)
+		if !errors.Is(err, provider.ErrInvalidSpec) {
+			t.Errorf("Expected `%s` error, got `%v`", provider.ErrInvalidSpec, err)
 		}
 
 		if fc.updateCount != 0 {
@@ -367,11 +701,11 @@ func TestClient_Create(t *testing.T) {
 		}
 
 		scError := errors.New("StatusCakeError")
-		fc.updateFunc = func(sct *statuscake.Test) (*statuscake.Test, error) {
-			return nil, scError
+		fc.updateFunc = func(_ context.Context, sct *Test) (int, error) {
+			return 0, scError
 		}
 
-		_, err := cl.Create(tpl)
+		_, err := cl.Create(context.Background(), tpl)
 		if err != scError {
 			t.Errorf("Expected %s error, got %s", scError, err)
 		}
@@ -399,14 +733,14 @@ func TestClient_Update(t *testing.T) {
 			},
 		}
 
-		fc.updateFunc = func(sct *statuscake.Test) (*statuscake.Test, error) {
+		fc.updateFunc = func(_ context.Context, sct *Test) (int, error) {
 			if sct.TestID != 12345 {
 				t.Errorf("Expected TestID to be `12345`, got `%d`", sct.TestID)
 			}
-			return sct, nil
+			return 0, nil
 		}
 
-		if _, err := cl.Update("12345", tpl); err != nil {
+		if _, err := cl.Update(context.Background(), "12345", tpl); err != nil {
 			t.Errorf("Expected no error, got %s", err)
 		}
 
@@ -429,8 +763,8 @@ func TestClient_Update(t *testing.T) {
 			},
 		}
 
-		if _, err := cl.Update("12345", tpl); err == nil {
-			t.Errorf("Expected error, got none")
+		if _, err := cl.Update(context.Background(), "12345", tpl); !errors.Is(err, provider.ErrInvalidSpec) {
+			t.Errorf("Expected `%s` error, got `%v`", provider.ErrInvalidSpec, err)
 		}
 
 		if fc.updateCount != 0 {
@@ -452,11 +786,11 @@ func TestClient_Update(t *testing.T) {
 		}
 
 		scError := errors.New("StatusCakeError")
-		fc.updateFunc = func(sct *statuscake.Test) (*statuscake.Test, error) {
-			return nil, scError
+		fc.updateFunc = func(_ context.Context, sct *Test) (int, error) {
+			return 0, scError
 		}
 
-		if _, err := cl.Update("12345", tpl); err != scError {
+		if _, err := cl.Update(context.Background(), "12345", tpl); err != scError {
 			t.Errorf("Expected %s error, got %s", scError, err)
 		}
 
@@ -465,6 +799,63 @@ func TestClient_Update(t *testing.T) {
 		}
 	})
 
+	t.Run("monitor not found", func(t *testing.T) {
+		defer fc.flush()
+
+		fc.updateFunc = func(_ context.Context, sct *Test) (int, error) {
+			return 0, &APIError{StatusCode: 200, Message: "No matching key can be found on this account.", err: provider.ErrNotFound}
+		}
+
+		tpl := v1alpha1.MonitorTemplateSpec{Type: "HTTP"}
+		if _, err := cl.Update(context.Background(), "12345", tpl); !errors.Is(err, provider.ErrNotFound) {
+			t.Errorf("Expected `%s` error, got `%s`", provider.ErrNotFound, err)
+		}
+
+		if fc.updateCount != 1 {
+			t.Errorf("Expected 1 update call, got %d", fc.updateCount)
+		}
+	})
+
+	t.Run("without changes", func(t *testing.T) {
+		defer fc.flush()
+
+		fc.updateFunc = func(_ context.Context, sct *Test) (int, error) {
+			return 0, &APIError{StatusCode: 200, Message: "No data has been updated (is any data different?)", err: provider.ErrNoChange}
+		}
+
+		tpl := v1alpha1.MonitorTemplateSpec{Type: "HTTP"}
+		id, err := cl.Update(context.Background(), "12345", tpl)
+		if !errors.Is(err, provider.ErrNoChange) {
+			t.Errorf("Expected `%s` error, got `%v`", provider.ErrNoChange, err)
+		}
+
+		if id != "12345" {
+			t.Errorf("Expected ID to be `12345`, got `%s`", id)
+		}
+	})
+
+	t.Run("without changes and unsupported assertions", func(t *testing.T) {
+		defer fc.flush()
+
+		fc.updateFunc = func(_ context.Context, sct *Test) (int, error) {
+			return 0, &APIError{StatusCode: 200, Message: "No data has been updated (is any data different?)", err: provider.ErrNoChange}
+		}
+
+		tpl := v1alpha1.MonitorTemplateSpec{
+			Type: "HTTP",
+			HTTP: &v1alpha1.HTTPTemplate{
+				URL: "http://fully-qualified-url.com",
+				Assertions: []v1alpha1.Assertion{
+					{Header: &v1alpha1.HeaderAssertion{Name: "X-Version", Equals: "2"}},
+				},
+			},
+		}
+		var unsupported *provider.UnsupportedAssertionsError
+		if _, err := cl.Update(context.Background(), "12345", tpl); !errors.As(err, &unsupported) {
+			t.Errorf("Expected unsupported assertions, got `%v`", err)
+		}
+	})
+
 	t.Run("with changed fields", func(t *testing.T) {
 		defer fc.flush()
 
@@ -478,17 +869,16 @@ func TestClient_Update(t *testing.T) {
 			},
 		}
 
-		fc.updateFunc = func(sct *statuscake.Test) (*statuscake.Test, error) {
+		fc.updateFunc = func(_ context.Context, sct *Test) (int, error) {
 			if sct.TestID != 12345 {
 				t.Errorf("Expected TestID to be `12345`, got `%d`", sct.TestID)
 			}
 
-			// StatusCake sets the ID to 0 if there's changes.
-			sct.TestID = 0
-			return sct, nil
+			// StatusCake returns no ID if there's changes.
+			return 0, nil
 		}
 
-		id, err := cl.Update("12345", tpl)
+		id, err := cl.Update(context.Background(), "12345", tpl)
 		if err != nil {
 			t.Errorf("Expected no error, got %s", err)
 		}
@@ -503,22 +893,321 @@ func TestClient_Update(t *testing.T) {
 	})
 }
 
+func TestTranslateTest(t *testing.T) {
+	spec := translateTest(&Test{
+		TestID:       12345,
+		WebsiteName:  "test-go-ingress",
+		TestType:     "HTTP",
+		WebsiteURL:   "https://api.example.com/_healthz",
+		FindString:   "OK",
+		CheckRate:    300,
+		Timeout:      10,
+		Confirmation: 2,
+		StatusCodes:  []string{"500", "503"},
+	})
+
+	confirmations := 2
+	exp := v1alpha1.MonitorTemplateSpec{
+		Name:          "test-go-ingress",
+		Type:          "HTTP",
+		CheckRate:     ptrString("5m0s"),
+		Timeout:       ptrString("10s"),
+		Confirmations: &confirmations,
+		HTTP: &v1alpha1.HTTPTemplate{
+			URL:              "https://api.example.com/_healthz",
+			ShouldContain:    "OK",
+			AlertStatusCodes: []int{500, 503},
+		},
+	}
+	if !reflect.DeepEqual(exp, spec) {
+		t.Errorf("Expected spec to equal \n%#v\ngot\n%#v", exp, spec)
+	}
+}
+
+func TestClient_List(t *testing.T) {
+	fc := new(fakeClient)
+	cl := &Client{cl: fc}
+	defer fc.flush()
+
+	fc.testsFunc = func(context.Context) ([]*Test, error) {
+		return []*Test{
+			{TestID: 12345, WebsiteName: "first", TestType: "HTTP", DoNotFind: true, FindString: "error"},
+			{TestID: 67890, WebsiteName: "second", TestType: "TCP", WebsiteURL: "db.example.com", Port: 5432},
+		}, nil
+	}
+	fc.sslChecksFunc = func(context.Context) ([]*SSLCheck, error) {
+		return []*SSLCheck{
+			{ID: 13579, Domain: "https://api.example.com", AlertAt: []int{1, 7, 30}},
+		}, nil
+	}
+
+	monitors, err := cl.List(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if len(monitors) != 3 {
+		t.Fatalf("Expected 3 monitors, got %d", len(monitors))
+	}
+
+	if monitors[0].ID != "12345" || monitors[0].Spec.HTTP.ShouldNotContain != "error" {
+		t.Errorf("Expected the first monitor to be translated, got %#v", monitors[0])
+	}
+
+	exp := &v1alpha1.TCPTemplate{Host: "db.example.com", Port: 5432}
+	if monitors[1].ID != "67890" || monitors[1].Spec.HTTP != nil || !reflect.DeepEqual(exp, monitors[1].Spec.TCP) {
+		t.Errorf("Expected the second monitor to be translated, got %#v", monitors[1])
+	}
+
+	if monitors[2].ID != "ssl-13579" || monitors[2].Spec.Type != "Certificate" {
+		t.Errorf("Expected the SSL check to be translated, got %#v", monitors[2])
+	}
+}
+
+func TestClient_Pause(t *testing.T) {
+	fc := new(fakeClient)
+	cl := &Client{cl: fc}
+
+	t.Run("pausing a running test", func(t *testing.T) {
+		defer fc.flush()
+
+		fc.detailFunc = func(context.Context, int) (*Test, error) {
+			return &Test{TestID: 12345, WebsiteName: "first", CheckRate: 300}, nil
+		}
+		fc.updateFunc = func(_ context.Context, test *Test) (int, error) {
+			if test.Paused == nil || !*test.Paused || test.TestID != 12345 || test.CheckRate != 300 {
+				t.Errorf("Expected the unchanged test to be paused, got %#v", test)
+			}
+			return 0, nil
+		}
+
+		if err := cl.Pause(context.Background(), "12345"); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+
+		if fc.updateCount != 1 {
+			t.Errorf("Expected 1 update, got %d", fc.updateCount)
+		}
+	})
+
+	t.Run("resuming a running test", func(t *testing.T) {
+		defer fc.flush()
+
+		fc.detailFunc = func(context.Context, int) (*Test, error) {
+			return &Test{TestID: 12345, WebsiteName: "first"}, nil
+		}
+
+		if err := cl.Resume(context.Background(), "12345"); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+
+		if fc.updateCount != 0 {
+			t.Errorf("Expected no updates, got %d", fc.updateCount)
+		}
+	})
+
+	t.Run("pausing an SSL check", func(t *testing.T) {
+		defer fc.flush()
+
+		if err := cl.Pause(context.Background(), "ssl-12345"); !errors.Is(err, provider.ErrPauseUnsupported) {
+			t.Errorf("Expected `%s` error, got `%s`", provider.ErrPauseUnsupported, err)
+		}
+	})
+
+	t.Run("pausing a test which doesn't exist", func(t *testing.T) {
+		defer fc.flush()
+
+		fc.detailFunc = func(context.Context, int) (*Test, error) {
+			return nil, &APIError{StatusCode: 200, Message: "No matching key can be found on this account.", err: provider.ErrNotFound}
+		}
+
+		if err := cl.Pause(context.Background(), "12345"); !errors.Is(err, provider.ErrNotFound) {
+			t.Errorf("Expected `%s` error, got `%s`", provider.ErrNotFound, err)
+		}
+	})
+}
+
+func TestClient_Certificate(t *testing.T) {
+	fc := new(fakeClient)
+	cl := &Client{cl: fc, groups: []string{"12345"}}
+
+	spec := v1alpha1.MonitorTemplateSpec{
+		Type:        "Certificate",
+		Certificate: &v1alpha1.CertificateTemplate{Host: "api.example.com"},
+	}
+
+	t.Run("create", func(t *testing.T) {
+		defer fc.flush()
+
+		fc.updateSSLFunc = func(_ context.Context, check *SSLCheck) (int, error) {
+			exp := &SSLCheck{
+				Domain:        "https://api.example.com",
+				CheckRate:     86400,
+				ContactGroups: []string{"12345"},
+				AlertAt:       []int{1, 7, 30},
+				AlertExpiry:   true,
+				AlertReminder: true,
+				AlertBroken:   true,
+			}
+			if !reflect.DeepEqual(exp, check) {
+				t.Errorf("Expected SSL check to equal \n%#v\ngot\n%#v", exp, check)
+			}
+
+			return 13579, nil
+		}
+
+		id, err := cl.Create(context.Background(), spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if id != "ssl-13579" {
+			t.Errorf("Expected ID to be `ssl-13579`, got `%s`", id)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		defer fc.flush()
+
+		fc.updateSSLFunc = func(_ context.Context, check *SSLCheck) (int, error) {
+			if check.ID != 13579 {
+				t.Errorf("Expected ID to be 13579, got %d", check.ID)
+			}
+			return 0, nil
+		}
+
+		id, err := cl.Update(context.Background(), "ssl-13579", spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if id != "ssl-13579" {
+			t.Errorf("Expected ID to be `ssl-13579`, got `%s`", id)
+		}
+	})
+
+	t.Run("update from a test", func(t *testing.T) {
+		defer fc.flush()
+
+		fc.deleteFunc = func(_ context.Context, i int) error {
+			if i != 12345 {
+				t.Errorf("Expected id `12345`, got `%d`", i)
+			}
+			return nil
+		}
+
+		if _, err := cl.Update(context.Background(), "12345", spec); !errors.Is(err, provider.ErrNotFound) {
+			t.Errorf("Expected `%s` error, got `%v`", provider.ErrNotFound, err)
+		}
+
+		if fc.deleteCount != 1 || fc.updateSSLCount != 0 {
+			t.Errorf("Expected the test to be deleted, got %d deletes and %d SSL updates", fc.deleteCount, fc.updateSSLCount)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		defer fc.flush()
+
+		fc.deleteSSLFunc = func(_ context.Context, i int) error {
+			if i != 13579 {
+				t.Errorf("Expected id `13579`, got `%d`", i)
+			}
+			return nil
+		}
+
+		if err := cl.Delete(context.Background(), "ssl-13579"); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("translated back", func(t *testing.T) {
+		got := translateSSLCheck(&SSLCheck{ID: 13579, Domain: "https://api.example.com", CheckRate: 3600, AlertAt: []int{1, 14, 30}})
+
+		exp := v1alpha1.MonitorTemplateSpec{
+			Name:      "api.example.com",
+			Type:      "Certificate",
+			CheckRate: ptrString("1h0m0s"),
+			Certificate: &v1alpha1.CertificateTemplate{
+				Host:              "api.example.com",
+				WarningThresholds: []string{"720h0m0s", "336h0m0s", "24h0m0s"},
+			},
+		}
+		if !reflect.DeepEqual(exp, got) {
+			t.Errorf("Expected spec to equal \n%#v\ngot\n%#v", exp, got)
+		}
+	})
+
+	t.Run("with invalid thresholds", func(t *testing.T) {
+		defer fc.flush()
+
+		invalid := spec
+		invalid.Certificate = &v1alpha1.CertificateTemplate{
+			Host:              "api.example.com",
+			WarningThresholds: []string{"24h"},
+		}
+
+		if _, err := cl.Create(context.Background(), invalid); !errors.Is(err, provider.ErrInvalidSpec) {
+			t.Errorf("Expected `%s` error, got `%v`", provider.ErrInvalidSpec, err)
+		}
+	})
+}
+
 type fakeClient struct {
-	deleteFunc  func(int) error
+	deleteFunc  func(context.Context, int) error
 	deleteCount int
 
-	updateFunc  func(*statuscake.Test) (*statuscake.Test, error)
+	updateFunc  func(context.Context, *Test) (int, error)
 	updateCount int
+
+	detailFunc  func(context.Context, int) (*Test, error)
+	detailCount int
+
+	testsFunc  func(context.Context) ([]*Test, error)
+	testsCount int
+
+	updateSSLFunc  func(context.Context, *SSLCheck) (int, error)
+	updateSSLCount int
+
+	deleteSSLFunc  func(context.Context, int) error
+	deleteSSLCount int
+
+	sslChecksFunc  func(context.Context) ([]*SSLCheck, error)
+	sslChecksCount int
 }
 
-func (c *fakeClient) Delete(i int) error {
+func (c *fakeClient) Delete(ctx context.Context, i int) error {
 	c.deleteCount++
-	return c.deleteFunc(i)
+	return c.deleteFunc(ctx, i)
 }
 
-func (c *fakeClient) Update(t *statuscake.Test) (*statuscake.Test, error) {
+func (c *fakeClient) Update(ctx context.Context, t *Test) (int, error) {
 	c.updateCount++
-	return c.updateFunc(t)
+	return c.updateFunc(ctx, t)
+}
+
+func (c *fakeClient) Detail(ctx context.Context, i int) (*Test, error) {
+	c.detailCount++
+	return c.detailFunc(ctx, i)
+}
+
+func (c *fakeClient) Tests(ctx context.Context) ([]*Test, error) {
+	c.testsCount++
+	return c.testsFunc(ctx)
+}
+
+func (c *fakeClient) UpdateSSL(ctx context.Context, check *SSLCheck) (int, error) {
+	c.updateSSLCount++
+	return c.updateSSLFunc(ctx, check)
+}
+
+func (c *fakeClient) DeleteSSL(ctx context.Context, i int) error {
+	c.deleteSSLCount++
+	return c.deleteSSLFunc(ctx, i)
+}
+
+func (c *fakeClient) SSLChecks(ctx context.Context) ([]*SSLCheck, error) {
+	c.sslChecksCount++
+	return c.sslChecksFunc(ctx)
 }
 
 func (c *fakeClient) flush() {
@@ -527,6 +1216,25 @@ func (c *fakeClient) flush() {
 
 	c.updateFunc = nil
 	c.updateCount = 0
+
+	c.detailFunc = nil
+	c.detailCount = 0
+
+	c.testsFunc = nil
+	c.testsCount = 0
+
+	c.updateSSLFunc = nil
+	c.updateSSLCount = 0
+
+	c.deleteSSLFunc = nil
+	c.deleteSSLCount = 0
+
+	c.sslChecksFunc = nil
+	c.sslChecksCount = 0
+}
+
+func ptrBool(b bool) *bool {
+	return &b
 }
 
 func ptrString(s string) *string {
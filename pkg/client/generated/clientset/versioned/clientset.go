@@ -25,7 +25,8 @@
 package versioned
 
 import (
-	glog "github.com/golang/glog"
+	"fmt"
+
 	ingressmonitorv1alpha1 "github.com/jelmersnoeck/ingress-monitor/pkg/client/generated/clientset/versioned/typed/ingressmonitor/v1alpha1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
@@ -35,8 +36,6 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	IngressmonitorV1alpha1() ingressmonitorv1alpha1.IngressmonitorV1alpha1Interface
-	// Deprecated: please explicitly pick a version if possible.
-	Ingressmonitor() ingressmonitorv1alpha1.IngressmonitorV1alpha1Interface
 }
 
 // Clientset contains the clients for groups. Each group has exactly one
@@ -51,12 +50,6 @@ func (c *Clientset) IngressmonitorV1alpha1() ingressmonitorv1alpha1.Ingressmonit
 	return c.ingressmonitorV1alpha1
 }
 
-// Deprecated: Ingressmonitor retrieves the default version of IngressmonitorClient.
-// Please explicitly pick a version.
-func (c *Clientset) Ingressmonitor() ingressmonitorv1alpha1.IngressmonitorV1alpha1Interface {
-	return c.ingressmonitorV1alpha1
-}
-
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -66,9 +59,14 @@ func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 }
 
 // NewForConfig creates a new Clientset for the given config.
+// If config's RateLimiter is not set and QPS and Burst are acceptable,
+// NewForConfig will generate a rate-limiter in configShallowCopy.
 func NewForConfig(c *rest.Config) (*Clientset, error) {
 	configShallowCopy := *c
 	if configShallowCopy.RateLimiter == nil && configShallowCopy.QPS > 0 {
+		if configShallowCopy.Burst <= 0 {
+			return nil, fmt.Errorf("burst is required to be greater than 0 when RateLimiter is not set and QPS is set to greater than 0")
+		}
 		configShallowCopy.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(configShallowCopy.QPS, configShallowCopy.Burst)
 	}
 	var cs Clientset
@@ -80,7 +78,6 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
 	if err != nil {
-		glog.Errorf("failed to create the DiscoveryClient: %v", err)
 		return nil, err
 	}
 	return &cs, nil
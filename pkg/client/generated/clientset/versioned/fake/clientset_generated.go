@@ -47,9 +47,10 @@ func NewSimpleClientset(objects ...runtime.Object) *Clientset {
 		}
 	}
 
-	fakePtr := testing.Fake{}
-	fakePtr.AddReactor("*", "*", testing.ObjectReaction(o))
-	fakePtr.AddWatchReactor("*", func(action testing.Action) (handled bool, ret watch.Interface, err error) {
+	cs := &Clientset{tracker: o}
+	cs.discovery = &fakediscovery.FakeDiscovery{Fake: &cs.Fake}
+	cs.AddReactor("*", "*", testing.ObjectReaction(o))
+	cs.AddWatchReactor("*", func(action testing.Action) (handled bool, ret watch.Interface, err error) {
 		gvr := action.GetResource()
 		ns := action.GetNamespace()
 		watch, err := o.Watch(gvr, ns)
@@ -59,7 +60,7 @@ func NewSimpleClientset(objects ...runtime.Object) *Clientset {
 		return true, watch, nil
 	})
 
-	return &Clientset{fakePtr, &fakediscovery.FakeDiscovery{Fake: &fakePtr}}
+	return cs
 }
 
 // Clientset implements clientset.Interface. Meant to be embedded into a
@@ -68,20 +69,20 @@ func NewSimpleClientset(objects ...runtime.Object) *Clientset {
 type Clientset struct {
 	testing.Fake
 	discovery *fakediscovery.FakeDiscovery
+	tracker   testing.ObjectTracker
 }
 
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	return c.discovery
 }
 
+func (c *Clientset) Tracker() testing.ObjectTracker {
+	return c.tracker
+}
+
 var _ clientset.Interface = &Clientset{}
 
 // IngressmonitorV1alpha1 retrieves the IngressmonitorV1alpha1Client
 func (c *Clientset) IngressmonitorV1alpha1() ingressmonitorv1alpha1.IngressmonitorV1alpha1Interface {
 	return &fakeingressmonitorv1alpha1.FakeIngressmonitorV1alpha1{Fake: &c.Fake}
 }
-
-// Ingressmonitor retrieves the IngressmonitorV1alpha1Client
-func (c *Clientset) Ingressmonitor() ingressmonitorv1alpha1.IngressmonitorV1alpha1Interface {
-	return &fakeingressmonitorv1alpha1.FakeIngressmonitorV1alpha1{Fake: &c.Fake}
-}
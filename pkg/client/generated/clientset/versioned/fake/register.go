@@ -30,15 +30,19 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	serializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 )
 
 var scheme = runtime.NewScheme()
 var codecs = serializer.NewCodecFactory(scheme)
 var parameterCodec = runtime.NewParameterCodec(scheme)
+var localSchemeBuilder = runtime.SchemeBuilder{
+	ingressmonitorv1alpha1.AddToScheme,
+}
 
 func init() {
 	v1.AddToGroupVersion(scheme, schema.GroupVersion{Version: "v1"})
-	AddToScheme(scheme)
+	utilruntime.Must(AddToScheme(scheme))
 }
 
 // AddToScheme adds all types of this clientset into the given scheme. This allows composition
@@ -55,6 +59,4 @@ func init() {
 //
 // After this, RawExtensions in Kubernetes types will serialize kube-aggregator types
 // correctly.
-func AddToScheme(scheme *runtime.Scheme) {
-	ingressmonitorv1alpha1.AddToScheme(scheme)
-}
+var AddToScheme = localSchemeBuilder.AddToScheme
@@ -25,6 +25,8 @@
 package fake
 
 import (
+	"context"
+
 	v1alpha1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
@@ -45,7 +47,7 @@ var monitorsResource = schema.GroupVersionResource{Group: "ingressmonitor.sphc.i
 var monitorsKind = schema.GroupVersionKind{Group: "ingressmonitor.sphc.io", Version: "v1alpha1", Kind: "Monitor"}
 
 // Get takes name of the monitor, and returns the corresponding monitor object, and an error if there is any.
-func (c *FakeMonitors) Get(name string, options v1.GetOptions) (result *v1alpha1.Monitor, err error) {
+func (c *FakeMonitors) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Monitor, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewGetAction(monitorsResource, c.ns, name), &v1alpha1.Monitor{})
 
@@ -56,7 +58,7 @@ func (c *FakeMonitors) Get(name string, options v1.GetOptions) (result *v1alpha1
 }
 
 // List takes label and field selectors, and returns the list of Monitors that match those selectors.
-func (c *FakeMonitors) List(opts v1.ListOptions) (result *v1alpha1.MonitorList, err error) {
+func (c *FakeMonitors) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.MonitorList, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewListAction(monitorsResource, monitorsKind, c.ns, opts), &v1alpha1.MonitorList{})
 
@@ -68,7 +70,7 @@ func (c *FakeMonitors) List(opts v1.ListOptions) (result *v1alpha1.MonitorList,
 	if label == nil {
 		label = labels.Everything()
 	}
-	list := &v1alpha1.MonitorList{}
+	list := &v1alpha1.MonitorList{ListMeta: obj.(*v1alpha1.MonitorList).ListMeta}
 	for _, item := range obj.(*v1alpha1.MonitorList).Items {
 		if label.Matches(labels.Set(item.Labels)) {
 			list.Items = append(list.Items, item)
@@ -78,14 +80,14 @@ func (c *FakeMonitors) List(opts v1.ListOptions) (result *v1alpha1.MonitorList,
 }
 
 // Watch returns a watch.Interface that watches the requested monitors.
-func (c *FakeMonitors) Watch(opts v1.ListOptions) (watch.Interface, error) {
+func (c *FakeMonitors) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
 	return c.Fake.
 		InvokesWatch(testing.NewWatchAction(monitorsResource, c.ns, opts))
 
 }
 
 // Create takes the representation of a monitor and creates it.  Returns the server's representation of the monitor, and an error, if there is any.
-func (c *FakeMonitors) Create(monitor *v1alpha1.Monitor) (result *v1alpha1.Monitor, err error) {
+func (c *FakeMonitors) Create(ctx context.Context, monitor *v1alpha1.Monitor, opts v1.CreateOptions) (result *v1alpha1.Monitor, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewCreateAction(monitorsResource, c.ns, monitor), &v1alpha1.Monitor{})
 
@@ -96,7 +98,7 @@ func (c *FakeMonitors) Create(monitor *v1alpha1.Monitor) (result *v1alpha1.Monit
 }
 
 // Update takes the representation of a monitor and updates it. Returns the server's representation of the monitor, and an error, if there is any.
-func (c *FakeMonitors) Update(monitor *v1alpha1.Monitor) (result *v1alpha1.Monitor, err error) {
+func (c *FakeMonitors) Update(ctx context.Context, monitor *v1alpha1.Monitor, opts v1.UpdateOptions) (result *v1alpha1.Monitor, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewUpdateAction(monitorsResource, c.ns, monitor), &v1alpha1.Monitor{})
 
@@ -106,8 +108,20 @@ func (c *FakeMonitors) Update(monitor *v1alpha1.Monitor) (result *v1alpha1.Monit
 	return obj.(*v1alpha1.Monitor), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeMonitors) UpdateStatus(ctx context.Context, monitor *v1alpha1.Monitor, opts v1.UpdateOptions) (*v1alpha1.Monitor, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(monitorsResource, "status", c.ns, monitor), &v1alpha1.Monitor{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Monitor), err
+}
+
 // Delete takes name of the monitor and deletes it. Returns an error if one occurs.
-func (c *FakeMonitors) Delete(name string, options *v1.DeleteOptions) error {
+func (c *FakeMonitors) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
 		Invokes(testing.NewDeleteAction(monitorsResource, c.ns, name), &v1alpha1.Monitor{})
 
@@ -115,17 +129,17 @@ func (c *FakeMonitors) Delete(name string, options *v1.DeleteOptions) error {
 }
 
 // DeleteCollection deletes a collection of objects.
-func (c *FakeMonitors) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
-	action := testing.NewDeleteCollectionAction(monitorsResource, c.ns, listOptions)
+func (c *FakeMonitors) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(monitorsResource, c.ns, listOpts)
 
 	_, err := c.Fake.Invokes(action, &v1alpha1.MonitorList{})
 	return err
 }
 
 // Patch applies the patch and returns the patched monitor.
-func (c *FakeMonitors) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Monitor, err error) {
+func (c *FakeMonitors) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Monitor, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(monitorsResource, c.ns, name, data, subresources...), &v1alpha1.Monitor{})
+		Invokes(testing.NewPatchSubresourceAction(monitorsResource, c.ns, name, pt, data, subresources...), &v1alpha1.Monitor{})
 
 	if obj == nil {
 		return nil, err
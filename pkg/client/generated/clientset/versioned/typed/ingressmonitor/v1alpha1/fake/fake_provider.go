@@ -25,6 +25,8 @@
 package fake
 
 import (
+	"context"
+
 	v1alpha1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
@@ -45,7 +47,7 @@ var providersResource = schema.GroupVersionResource{Group: "ingressmonitor.sphc.
 var providersKind = schema.GroupVersionKind{Group: "ingressmonitor.sphc.io", Version: "v1alpha1", Kind: "Provider"}
 
 // Get takes name of the provider, and returns the corresponding provider object, and an error if there is any.
-func (c *FakeProviders) Get(name string, options v1.GetOptions) (result *v1alpha1.Provider, err error) {
+func (c *FakeProviders) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Provider, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewGetAction(providersResource, c.ns, name), &v1alpha1.Provider{})
 
@@ -56,7 +58,7 @@ func (c *FakeProviders) Get(name string, options v1.GetOptions) (result *v1alpha
 }
 
 // List takes label and field selectors, and returns the list of Providers that match those selectors.
-func (c *FakeProviders) List(opts v1.ListOptions) (result *v1alpha1.ProviderList, err error) {
+func (c *FakeProviders) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ProviderList, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewListAction(providersResource, providersKind, c.ns, opts), &v1alpha1.ProviderList{})
 
@@ -68,7 +70,7 @@ func (c *FakeProviders) List(opts v1.ListOptions) (result *v1alpha1.ProviderList
 	if label == nil {
 		label = labels.Everything()
 	}
-	list := &v1alpha1.ProviderList{}
+	list := &v1alpha1.ProviderList{ListMeta: obj.(*v1alpha1.ProviderList).ListMeta}
 	for _, item := range obj.(*v1alpha1.ProviderList).Items {
 		if label.Matches(labels.Set(item.Labels)) {
 			list.Items = append(list.Items, item)
@@ -78,14 +80,14 @@ func (c *FakeProviders) List(opts v1.ListOptions) (result *v1alpha1.ProviderList
 }
 
 // Watch returns a watch.Interface that watches the requested providers.
-func (c *FakeProviders) Watch(opts v1.ListOptions) (watch.Interface, error) {
+func (c *FakeProviders) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
 	return c.Fake.
 		InvokesWatch(testing.NewWatchAction(providersResource, c.ns, opts))
 
 }
 
 // Create takes the representation of a provider and creates it.  Returns the server's representation of the provider, and an error, if there is any.
-func (c *FakeProviders) Create(provider *v1alpha1.Provider) (result *v1alpha1.Provider, err error) {
+func (c *FakeProviders) Create(ctx context.Context, provider *v1alpha1.Provider, opts v1.CreateOptions) (result *v1alpha1.Provider, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewCreateAction(providersResource, c.ns, provider), &v1alpha1.Provider{})
 
@@ -96,7 +98,7 @@ func (c *FakeProviders) Create(provider *v1alpha1.Provider) (result *v1alpha1.Pr
 }
 
 // Update takes the representation of a provider and updates it. Returns the server's representation of the provider, and an error, if there is any.
-func (c *FakeProviders) Update(provider *v1alpha1.Provider) (result *v1alpha1.Provider, err error) {
+func (c *FakeProviders) Update(ctx context.Context, provider *v1alpha1.Provider, opts v1.UpdateOptions) (result *v1alpha1.Provider, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewUpdateAction(providersResource, c.ns, provider), &v1alpha1.Provider{})
 
@@ -107,7 +109,7 @@ func (c *FakeProviders) Update(provider *v1alpha1.Provider) (result *v1alpha1.Pr
 }
 
 // Delete takes name of the provider and deletes it. Returns an error if one occurs.
-func (c *FakeProviders) Delete(name string, options *v1.DeleteOptions) error {
+func (c *FakeProviders) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
 		Invokes(testing.NewDeleteAction(providersResource, c.ns, name), &v1alpha1.Provider{})
 
@@ -115,17 +117,17 @@ func (c *FakeProviders) Delete(name string, options *v1.DeleteOptions) error {
 }
 
 // DeleteCollection deletes a collection of objects.
-func (c *FakeProviders) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
-	action := testing.NewDeleteCollectionAction(providersResource, c.ns, listOptions)
+func (c *FakeProviders) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(providersResource, c.ns, listOpts)
 
 	_, err := c.Fake.Invokes(action, &v1alpha1.ProviderList{})
 	return err
 }
 
 // Patch applies the patch and returns the patched provider.
-func (c *FakeProviders) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Provider, err error) {
+func (c *FakeProviders) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Provider, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(providersResource, c.ns, name, data, subresources...), &v1alpha1.Provider{})
+		Invokes(testing.NewPatchSubresourceAction(providersResource, c.ns, name, pt, data, subresources...), &v1alpha1.Provider{})
 
 	if obj == nil {
 		return nil, err
@@ -25,6 +25,7 @@
 package v1alpha1
 
 import (
+	"context"
 	time "time"
 
 	ingressmonitorv1alpha1 "github.com/jelmersnoeck/ingress-monitor/apis/ingressmonitor/v1alpha1"
@@ -67,13 +68,13 @@ func NewFilteredIngressMonitorInformer(client versioned.Interface, namespace str
 				if tweakListOptions != nil {
 					tweakListOptions(&options)
 				}
-				return client.IngressmonitorV1alpha1().IngressMonitors(namespace).List(options)
+				return client.IngressmonitorV1alpha1().IngressMonitors(namespace).List(context.TODO(), options)
 			},
 			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
 				if tweakListOptions != nil {
 					tweakListOptions(&options)
 				}
-				return client.IngressmonitorV1alpha1().IngressMonitors(namespace).Watch(options)
+				return client.IngressmonitorV1alpha1().IngressMonitors(namespace).Watch(context.TODO(), options)
 			},
 		},
 		&ingressmonitorv1alpha1.IngressMonitor{},